@@ -0,0 +1,47 @@
+package dotenv
+
+import "strings"
+
+// The name given to settings from the command line in errors and by Source.
+const argsName = "<args>"
+
+// ApplyArgs sets environment variables from the leading KEY=VALUE arguments, the way env does in
+// the shell, e.g. "./server LOG_LEVEL=debug PORT=9090".  Scanning stops at the first argument that
+// isn't an assignment to a valid environment variable name, so a flag such as --flag=value is never
+// mistaken for a setting.  Returns the rest of the arguments untouched, for flag parsing.
+//
+// The values are used as is, without quotes or variable expansion, and always override existing
+// environment variables, so call ApplyArgs after Load to apply them last:
+//
+//	remaining, err := dotenv.ApplyArgs(os.Args[1:])
+func ApplyArgs(args []string) (remaining []string, err error) {
+	opts := currentOptions()
+	opts.AllowInvalidKeys = false
+
+	var assignments []assignment
+
+	for i, arg := range args {
+		name, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			break
+		}
+
+		// check the name as given, before NormalizeKeys turns "--flag" into "__FLAG"
+		if !validKey(name) {
+			break
+		}
+
+		key, err := checkKey(name, opts)
+		if err != nil {
+			break
+		}
+
+		assignments = append(assignments, assignment{Key: key, Value: value, File: argsName, Line: i + 1})
+	}
+
+	if err := (&loader{override: true}).apply(assignments); err != nil {
+		return args, err
+	}
+
+	return args[len(assignments):], nil
+}
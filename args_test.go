@@ -0,0 +1,80 @@
+package dotenv
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestApplyArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      Options
+		args      []string
+		remaining []string
+		set       map[string]string
+	}{
+		{
+			name:      "assignments",
+			args:      []string{"ARGS_LEVEL=debug", "ARGS_PORT=9090", "serve"},
+			remaining: []string{"serve"},
+			set:       map[string]string{"ARGS_LEVEL": "debug", "ARGS_PORT": "9090"},
+		},
+		{
+			name:      "empty value",
+			args:      []string{"ARGS_LEVEL=", "serve"},
+			remaining: []string{"serve"},
+			set:       map[string]string{"ARGS_LEVEL": ""},
+		},
+		{
+			name:      "stops at a flag",
+			args:      []string{"ARGS_LEVEL=debug", "--flag=value", "ARGS_PORT=9090"},
+			remaining: []string{"--flag=value", "ARGS_PORT=9090"},
+			set:       map[string]string{"ARGS_LEVEL": "debug"},
+		},
+		{
+			name:      "flag with normalized keys",
+			opts:      Options{NormalizeKeys: true, CaseInsensitiveKeys: true},
+			args:      []string{"--flag=value", "ARGS_PORT=9090"},
+			remaining: []string{"--flag=value", "ARGS_PORT=9090"},
+			set:       map[string]string{},
+		},
+		{
+			name:      "normalized keys",
+			opts:      Options{NormalizeKeys: true, CaseInsensitiveKeys: true},
+			args:      []string{"args_level=debug", "-v"},
+			remaining: []string{"-v"},
+			set:       map[string]string{"ARGS_LEVEL": "debug"},
+		},
+		{
+			name:      "not an assignment",
+			args:      []string{"serve", "ARGS_PORT=9090"},
+			remaining: []string{"serve", "ARGS_PORT=9090"},
+			set:       map[string]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setOptions(t, test.opts)
+			unsetenv(t, "ARGS_LEVEL", "ARGS_PORT", "__FLAG")
+
+			remaining, err := ApplyArgs(test.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(remaining, test.remaining) {
+				t.Errorf("expected %v to remain; got %v", test.remaining, remaining)
+			}
+
+			for _, key := range []string{"ARGS_LEVEL", "ARGS_PORT", "__FLAG"} {
+				val, set := os.LookupEnv(key)
+				expected, ok := test.set[key]
+				if set != ok || val != expected {
+					t.Errorf("expected %s to be %q (set %v); got %q (set %v)", key, expected, ok, val, set)
+				}
+			}
+		})
+	}
+}
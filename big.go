@@ -0,0 +1,85 @@
+package dotenv
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// GetBigInt returns the environment variable as an arbitrarily large integer, such as a chain ID or
+// token amount that doesn't fit in an int64.  Accepts decimal, and hex with a "0x" prefix.  If the
+// environment variable doesn't exist or isn't an integer, returns a copy of the default value if
+// present, which may be registered as a string or *big.Int, otherwise returns nil.
+func GetBigInt(key string) *big.Int {
+	if val, set := lookupEnv(key); set {
+		if n, err := parseBigInt(val); err == nil {
+			return n
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *big.Int:
+			return new(big.Int).Set(defaultValue)
+		case string:
+			if n, err := parseBigInt(defaultValue); err == nil {
+				return n
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetRat returns the environment variable as an exact fraction, such as "3/4", "1.25", or "1e-18".
+// If the environment variable doesn't exist or isn't a number, returns a copy of the default value
+// if present, which may be registered as a string or *big.Rat, otherwise returns nil.
+func GetRat(key string) *big.Rat {
+	if val, set := lookupEnv(key); set {
+		if r, err := parseRat(val); err == nil {
+			return r
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *big.Rat:
+			return new(big.Rat).Set(defaultValue)
+		case string:
+			if r, err := parseRat(defaultValue); err == nil {
+				return r
+			}
+		}
+	}
+
+	return nil
+}
+
+// Parse the integer, in decimal or hex with a 0x prefix.
+func parseBigInt(val string) (*big.Int, error) {
+	val = strings.TrimSpace(val)
+
+	base := 10
+	digits := val
+	if s := strings.TrimPrefix(strings.TrimPrefix(val, "-"), "+"); strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		base = 16
+		digits = val[:len(val)-len(s)] + s[2:]
+	}
+
+	n, ok := new(big.Int).SetString(digits, base)
+	if !ok {
+		return nil, fmt.Errorf("%q is not an integer", val)
+	}
+
+	return n, nil
+}
+
+// Parse the fraction or decimal number.
+func parseRat(val string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(val))
+	if !ok {
+		return nil, fmt.Errorf("%q is not a number", val)
+	}
+
+	return r, nil
+}
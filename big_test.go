@@ -0,0 +1,104 @@
+package dotenv
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestGetBigInt(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected string
+	}{
+		{val: "123456789012345678901234567890", expected: "123456789012345678901234567890"},
+		{val: "-42", expected: "-42"},
+		{val: "0xff", expected: "255"},
+		{val: "-0XFF", expected: "-255"},
+		{val: " 7 ", expected: "7"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("BIG_INT", test.val)
+
+		if n := GetBigInt("BIG_INT"); n == nil || n.String() != test.expected {
+			t.Errorf("%q: expected %s; got %v", test.val, test.expected, n)
+		}
+	}
+
+	for _, val := range []string{"1.5", "0x", "ten", ""} {
+		t.Setenv("BIG_INT", val)
+
+		if n := GetBigInt("BIG_INT"); n != nil {
+			t.Errorf("%q: expected nil without a default; got %v", val, n)
+		}
+	}
+
+	unsetenv(t, "BIG_INT")
+	if n := GetBigInt("BIG_INT"); n != nil {
+		t.Errorf("expected nil when unset; got %v", n)
+	}
+}
+
+func TestGetBigIntDefault(t *testing.T) {
+	unsetenv(t, "BIG_INT_DEFAULT", "BIG_INT_STRING_DEFAULT")
+
+	defaultValue := big.NewInt(1000)
+	Register("BIG_INT_DEFAULT", defaultValue, "A *big.Int default")
+	Register("BIG_INT_STRING_DEFAULT", "0x10", "A string default")
+
+	n := GetBigInt("BIG_INT_DEFAULT")
+	if n == nil || n.Int64() != 1000 {
+		t.Fatalf("expected the default 1000; got %v", n)
+	}
+
+	n.SetInt64(1)
+	if defaultValue.Int64() != 1000 {
+		t.Error("expected a copy of the default, so changing it leaves the default alone")
+	}
+
+	if n := GetBigInt("BIG_INT_STRING_DEFAULT"); n == nil || n.Int64() != 16 {
+		t.Errorf("expected the string default to be parsed; got %v", n)
+	}
+
+	t.Setenv("BIG_INT_DEFAULT", "invalid")
+	if n := GetBigInt("BIG_INT_DEFAULT"); n == nil || n.Int64() != 1000 {
+		t.Errorf("expected the default when invalid; got %v", n)
+	}
+}
+
+func TestGetRat(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected string
+	}{
+		{val: "3/4", expected: "3/4"},
+		{val: "1.25", expected: "5/4"},
+		{val: "1e-18", expected: "1/1000000000000000000"},
+		{val: "-2", expected: "-2/1"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("BIG_RAT", test.val)
+
+		if r := GetRat("BIG_RAT"); r == nil || r.String() != test.expected {
+			t.Errorf("%q: expected %s; got %v", test.val, test.expected, r)
+		}
+	}
+
+	Register("BIG_RAT", big.NewRat(1, 2), "A *big.Rat default")
+
+	t.Setenv("BIG_RAT", "half")
+	if r := GetRat("BIG_RAT"); r == nil || r.String() != "1/2" {
+		t.Errorf("expected the default when invalid; got %v", r)
+	}
+
+	unsetenv(t, "BIG_RAT")
+	if r := GetRat("BIG_RAT"); r == nil || r.String() != "1/2" {
+		t.Errorf("expected the default when unset; got %v", r)
+	}
+
+	unsetenv(t, "BIG_RAT_MISSING")
+	if r := GetRat("BIG_RAT_MISSING"); r != nil {
+		t.Errorf("expected nil when unset without a default; got %v", r)
+	}
+}
@@ -0,0 +1,94 @@
+package dotenv
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is a number of bytes.  Register a ByteSize default for a setting read by GetBytes, and
+// Help displays it with a suffix, e.g. 25MB.
+type ByteSize int64
+
+// Size suffixes, from largest to smallest.
+var byteUnits = []struct {
+	suffix string
+	size   int64
+}{
+	{"TiB", 1 << 40},
+	{"TB", 1e12},
+	{"GiB", 1 << 30},
+	{"GB", 1e9},
+	{"MiB", 1 << 20},
+	{"MB", 1e6},
+	{"KiB", 1 << 10},
+	{"KB", 1e3},
+	{"B", 1},
+}
+
+// String formats the size with the most compact suffix that represents it exactly, e.g. "25MB" or
+// "1GiB".
+func (b ByteSize) String() string {
+	if b == 0 {
+		return "0B"
+	}
+
+	best := strconv.FormatInt(int64(b), 10) + "B"
+	for _, unit := range byteUnits {
+		if int64(b)%unit.size == 0 {
+			if s := strconv.FormatInt(int64(b)/unit.size, 10) + unit.suffix; len(s) < len(best) {
+				best = s
+			}
+		}
+	}
+
+	return best
+}
+
+// Parse the size, a number with an optional suffix, e.g. "25MB" or "1.5GiB".  The suffixes are
+// case-insensitive, and a bare number is a number of bytes.
+func parseBytes(val string) (int64, error) {
+	val = strings.TrimSpace(val)
+
+	num := strings.TrimRightFunc(val, func(c rune) bool {
+		return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+	})
+	suffix := val[len(num):]
+	num = strings.TrimSpace(num)
+
+	size := int64(1)
+	if suffix != "" {
+		size = 0
+		for _, unit := range byteUnits {
+			if strings.EqualFold(suffix, unit.suffix) {
+				size = unit.size
+				break
+			}
+		}
+
+		if size == 0 {
+			return 0, fmt.Errorf("invalid size suffix %q", suffix)
+		}
+	}
+
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if n < 0 || n > math.MaxInt64/size {
+			return 0, fmt.Errorf("size %q out of range", val)
+		}
+
+		return n * size, nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q", val)
+	}
+
+	total := f * float64(size)
+	if f < 0 || total >= math.MaxInt64 || math.IsNaN(total) {
+		return 0, fmt.Errorf("size %q out of range", val)
+	}
+
+	return int64(total), nil
+}
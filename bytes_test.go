@@ -0,0 +1,58 @@
+package dotenv
+
+import "testing"
+
+func TestParseBytes(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected int64
+		invalid  bool
+	}{
+		{val: "512", expected: 512},
+		{val: "25MB", expected: 25_000_000},
+		{val: "25mb", expected: 25_000_000},
+		{val: "1.5GiB", expected: 1536 * 1024 * 1024},
+		{val: "10 KiB", expected: 10 * 1024},
+		{val: "1TB", expected: 1_000_000_000_000},
+		{val: "lots", invalid: true},
+		{val: "10XB", invalid: true},
+	}
+
+	for _, test := range tests {
+		size, err := parseBytes(test.val)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("%q: expected an error; got %d", test.val, size)
+			}
+
+			continue
+		}
+
+		if err != nil || size != test.expected {
+			t.Errorf("%q: expected %d; got %d, %v", test.val, test.expected, size, err)
+		}
+	}
+}
+
+func TestGetBytesDefault(t *testing.T) {
+	unsetenv(t, "BYTES_SIZE", "BYTES_INT", "BYTES_INT64")
+
+	Register("BYTES_SIZE", ByteSize(1024), "A ByteSize")
+	Register("BYTES_INT", 2048, "An int")
+	Register("BYTES_INT64", int64(4096), "An int64")
+
+	tests := []struct {
+		key      string
+		expected int64
+	}{
+		{key: "BYTES_SIZE", expected: 1024},
+		{key: "BYTES_INT", expected: 2048},
+		{key: "BYTES_INT64", expected: 4096},
+	}
+
+	for _, test := range tests {
+		if size := GetBytes(test.key); size != test.expected {
+			t.Errorf("%s: expected %d; got %d", test.key, test.expected, size)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package dotenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A field of a cron expression, with its range and any names for its values.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+// The five fields of a cron expression, in order.
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// Shortcuts for common schedules.
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// GetCron returns the environment variable as a cron schedule, such as
+// CLEANUP_SCHEDULE="0 3 * * *", after checking it's valid, so a typo fails at startup.  Expects the
+// five standard fields, minute, hour, day of month, month, and day of week, each of which may be
+// "*", a number, a range such as "1-5", a step such as "*/15" or "0-30/5", or a comma-separated
+// list of these.  Months and days of the week may be given by name, e.g. "JAN" or "mon", and
+// shortcuts such as "@daily" are accepted.  Returns the schedule in canonical form, with single
+// spaces, numbers instead of names, shortcuts expanded, and Sunday as 0 except at the end of a
+// range.  If the environment variable doesn't exist, uses the default value if present, otherwise
+// returns ErrNotSet.  Returns a *ParseValueError naming the invalid field if the schedule is
+// invalid.
+func GetCron(key string) (string, error) {
+	val, set := lookupEnv(key)
+	if !set {
+		val = defaultString(key)
+		if val == "" {
+			return "", fmt.Errorf("%s: %w", key, ErrNotSet)
+		}
+	}
+
+	schedule, err := parseCron(val)
+	if err != nil {
+		return "", &ParseValueError{Key: key, Raw: val, Type: "cron schedule", Err: err}
+	}
+
+	return schedule, nil
+}
+
+// RegisterCron registers a default cron schedule for an environment variable read by GetCron.
+// Panics if the schedule is invalid, so a bad default is caught at startup.
+func RegisterCron(key, defaultValue, description string) {
+	if _, err := parseCron(defaultValue); err != nil {
+		panic(fmt.Sprintf("invalid default for %s: %s", key, err))
+	}
+
+	register(descriptor{Var: key, DefaultValue: defaultValue, Description: description})
+}
+
+// Check the cron expression and return it in canonical form.
+func parseCron(val string) (string, error) {
+	expr := strings.TrimSpace(val)
+	if macro, ok := cronMacros[strings.ToLower(expr)]; ok {
+		return macro, nil
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return "", fmt.Errorf("expected 5 fields, minute hour day-of-month month day-of-week, but found %d", len(fields))
+	}
+
+	for i, field := range fields {
+		canonical, err := cronFields[i].parse(field)
+		if err != nil {
+			return "", fmt.Errorf("%s field %q: %w", cronFields[i].name, field, err)
+		}
+
+		fields[i] = canonical
+	}
+
+	return strings.Join(fields, " "), nil
+}
+
+// Check the field, a comma-separated list of values, ranges, and steps, and return it with any
+// names replaced by numbers.
+func (f cronField) parse(field string) (string, error) {
+	items := strings.Split(field, ",")
+
+	for i, item := range items {
+		span, step, stepped := strings.Cut(item, "/")
+		if stepped {
+			n, err := strconv.Atoi(step)
+			if err != nil || n < 1 {
+				return "", fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		if span == "*" {
+			continue
+		}
+
+		lo, hi, ranged := strings.Cut(span, "-")
+
+		from, err := f.value(lo)
+		if err != nil {
+			return "", err
+		}
+
+		if !ranged && f.max == 7 && from == 7 {
+			from = 0
+		}
+
+		canonical := strconv.Itoa(from)
+
+		if ranged {
+			to, err := f.value(hi)
+			if err != nil {
+				return "", err
+			}
+
+			if from > to {
+				return "", fmt.Errorf("range %s starts after it ends", span)
+			}
+
+			canonical += "-" + strconv.Itoa(to)
+		}
+
+		if stepped {
+			canonical += "/" + step
+		}
+
+		items[i] = canonical
+	}
+
+	return strings.Join(items, ","), nil
+}
+
+// Parse a single value, a number or name, checking it's in range.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return i + f.min, nil
+		}
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is out of range %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
@@ -0,0 +1,70 @@
+package dotenv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGetCron(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected string
+		err      string
+	}{
+		{val: "0 3 * * *", expected: "0 3 * * *"},
+		{val: "  */15   9-17 * * 1-5 ", expected: "*/15 9-17 * * 1-5"},
+		{val: "0 0 1 jan,JUL mon-FRI", expected: "0 0 1 1,7 1-5"},
+		{val: "0 0 * * 7", expected: "0 0 * * 0"},
+		{val: "0 0 * * 5-7", expected: "0 0 * * 5-7"},
+		{val: "0-30/5 * * * *", expected: "0-30/5 * * * *"},
+		{val: "@Daily", expected: "0 0 * * *"},
+		{val: "0 3 * *", err: "expected 5 fields"},
+		{val: "60 * * * *", err: `minute field "60": 60 is out of range 0-59`},
+		{val: "* 24 * * *", err: "hour field"},
+		{val: "* * 0 * *", err: "day of month field"},
+		{val: "* * * FOO *", err: `month field "FOO": "FOO" is not a number`},
+		{val: "*/0 * * * *", err: `invalid step "0"`},
+		{val: "* 17-9 * * *", err: "range 17-9 starts after it ends"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("CRON_SCHEDULE", test.val)
+
+		schedule, err := GetCron("CRON_SCHEDULE")
+		if test.err == "" {
+			if err != nil || schedule != test.expected {
+				t.Errorf("%q: expected %q; got %q, %v", test.val, test.expected, schedule, err)
+			}
+			continue
+		}
+
+		var perr *ParseValueError
+		if !errors.As(err, &perr) || perr.Key != "CRON_SCHEDULE" || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%q: expected a *ParseValueError containing %q; got %v", test.val, test.err, err)
+		}
+	}
+}
+
+func TestGetCronDefault(t *testing.T) {
+	unsetenv(t, "CRON_DEFAULT", "CRON_MISSING")
+	RegisterCron("CRON_DEFAULT", "@hourly", "A default schedule")
+
+	if schedule, err := GetCron("CRON_DEFAULT"); err != nil || schedule != "0 * * * *" {
+		t.Errorf("expected the default schedule when unset; got %q, %v", schedule, err)
+	}
+
+	if _, err := GetCron("CRON_MISSING"); !errors.Is(err, ErrNotSet) {
+		t.Errorf("expected a missing schedule to be reported as not set; got %v", err)
+	}
+}
+
+func TestRegisterCronInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected an invalid default schedule to panic")
+		}
+	}()
+
+	RegisterCron("CRON_INVALID", "every day", "An invalid default")
+}
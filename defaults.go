@@ -2,6 +2,13 @@ package dotenv
 
 import (
 	"fmt"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"sync"
@@ -18,6 +25,33 @@ const (
 	Float64Type
 	BoolType
 	DurationType
+	UintType
+	Uint64Type
+	TimeType
+	URLType
+	IPType
+	CIDRType
+	CIDRSliceType
+	BytesType
+	IntSliceType
+	Int64SliceType
+	Float64SliceType
+	DurationSliceType
+	MapType
+	BinaryType
+	RegexpType
+	LogLevelType
+	TimeZoneType
+	PortType
+	StringSetType
+	IntRangeType
+	BigIntType
+	RatType
+	URLSliceType
+	IPSliceType
+	VersionType
+	HeaderType
+	Int64Type
 )
 
 type descriptor struct {
@@ -25,6 +59,9 @@ type descriptor struct {
 	DataType     int
 	DefaultValue interface{}
 	Description  string
+	Secret       bool
+	Allowed      []string
+	Validator    func(value string) error
 }
 
 // Cache default values for environment variables.
@@ -32,32 +69,247 @@ var registered = make(map[string]descriptor)
 var regMutex sync.RWMutex
 
 // Register registers a default value for an environment variable.  When getting the value for that
-// environment variable, if a value isn't set, the default is returned.
+// environment variable, if a value isn't set, the default is returned.  Panics if the default isn't
+// one of the supported types; see RegisterE.  Thread-safe.
 func Register(key string, defaultValue interface{}, description string) {
+	register(descriptor{Var: key, DefaultValue: defaultValue, Description: description})
+}
+
+// RegisterE registers a default value for an environment variable, like Register, but returns an
+// error naming the key and the type if the default isn't one of the supported types, rather than
+// panicking.  Useful when registering settings from a library's init function.
+func RegisterE(key string, defaultValue interface{}, description string) error {
+	return registerE(descriptor{Var: key, DefaultValue: defaultValue, Description: description})
+}
+
+// RegisterSecret registers a default value for an environment variable holding a secret, such as a
+// password, just like Register.  Help and the Must functions never display the value.
+func RegisterSecret(key string, defaultValue interface{}, description string) {
+	register(descriptor{Var: key, DefaultValue: defaultValue, Description: description, Secret: true})
+}
+
+// RegisterEnum registers a default value for an environment variable that must be one of the
+// allowed values, such as "json", "text", or "console".  GetEnum checks the value against them if
+// called without any, and Help lists them.
+func RegisterEnum(key, defaultValue, description string, allowed ...string) {
+	register(descriptor{
+		Var:          key,
+		DefaultValue: defaultValue,
+		Description:  description,
+		Allowed:      allowed,
+	})
+}
+
+// RegisterValidated registers a default value for an environment variable, like Register, along
+// with a function that checks the value meets any other constraints, e.g. that WORKERS is between
+// 1 and 256, or WEBHOOK_URL is https.  Validate calls validate with the effective value, from the
+// environment or the default, and Help notes the variable is validated.
+func RegisterValidated(key string, defaultValue interface{}, description string, validate func(value string) error) {
+	register(descriptor{
+		Var:          key,
+		DefaultValue: defaultValue,
+		Description:  description,
+		Validator:    validate,
+	})
+}
+
+// RegisterURLSlice registers a default list of absolute URLs for an environment variable read by
+// GetURLSlice, given as strings.  Panics if any of the URLs is invalid, so a bad default is caught
+// at startup.
+func RegisterURLSlice(key string, defaultValue []string, description string) {
+	urls := make([]*url.URL, len(defaultValue))
+	for i, val := range defaultValue {
+		v, err := parseURL(strings.TrimSpace(val))
+		if err != nil {
+			panic(fmt.Sprintf("invalid default for %s: element %d: %s", key, i, err))
+		}
+
+		urls[i] = v
+	}
+
+	register(descriptor{Var: key, DefaultValue: urls, Description: description})
+}
+
+// RegisterIPSlice registers a default list of IP addresses for an environment variable read by
+// GetIPSlice, given as strings.  Panics if any of the addresses is invalid, so a bad default is
+// caught at startup.
+func RegisterIPSlice(key string, defaultValue []string, description string) {
+	ips := make([]net.IP, len(defaultValue))
+	for i, val := range defaultValue {
+		v, err := parseIP(strings.TrimSpace(val))
+		if err != nil {
+			panic(fmt.Sprintf("invalid default for %s: element %d: %s", key, i, err))
+		}
+
+		ips[i] = v
+	}
+
+	register(descriptor{Var: key, DefaultValue: ips, Description: description})
+}
+
+// Register the descriptor, panicking if the type of the default isn't supported.
+func register(d descriptor) {
+	if err := registerE(d); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Register the descriptor, filling in the data type of the default.  The descriptor is stored
+// complete, so no other goroutine sees it without its secret flag, allowed values, or validator.
+func registerE(d descriptor) error {
 	var dataType int
 
-	switch defaultValue.(type) {
+	switch d.DefaultValue.(type) {
 	case string:
 		dataType = StringType
 	case []string:
 		dataType = StringSliceType
 	case int:
 		dataType = IntType
+	case int64:
+		dataType = Int64Type
 	case float64:
 		dataType = Float64Type
 	case bool:
 		dataType = BoolType
 	case time.Duration:
 		dataType = DurationType
+	case uint:
+		dataType = UintType
+	case uint64:
+		dataType = Uint64Type
+	case time.Time:
+		dataType = TimeType
+	case *url.URL:
+		dataType = URLType
+	case net.IP:
+		dataType = IPType
+	case *net.IPNet:
+		dataType = CIDRType
+	case []*net.IPNet:
+		dataType = CIDRSliceType
+	case ByteSize:
+		dataType = BytesType
+	case []int:
+		dataType = IntSliceType
+	case []int64:
+		dataType = Int64SliceType
+	case []float64:
+		dataType = Float64SliceType
+	case []time.Duration:
+		dataType = DurationSliceType
+	case map[string]string:
+		dataType = MapType
+	case []byte:
+		dataType = BinaryType
+	case *regexp.Regexp:
+		dataType = RegexpType
+	case slog.Level:
+		dataType = LogLevelType
+	case *time.Location:
+		dataType = TimeZoneType
+	case Port:
+		dataType = PortType
+	case map[string]struct{}:
+		dataType = StringSetType
+	case [2]int:
+		dataType = IntRangeType
+	case *big.Int:
+		dataType = BigIntType
+	case *big.Rat:
+		dataType = RatType
+	case []*url.URL:
+		dataType = URLSliceType
+	case []net.IP:
+		dataType = IPSliceType
+	case Version:
+		dataType = VersionType
+	case http.Header:
+		dataType = HeaderType
 	default:
-		panic("invalid type")
+		return fmt.Errorf("unable to register %s: default value type %T isn't supported", d.Var, d.DefaultValue)
 	}
 
-	registered[key] = descriptor{
-		Var:          key,
-		DataType:     dataType,
-		DefaultValue: defaultValue,
-		Description:  description,
+	d.Var = normalizeKey(d.Var)
+	d.DataType = dataType
+
+	regMutex.Lock()
+	defer regMutex.Unlock()
+
+	registered[d.Var] = d
+
+	return nil
+}
+
+// Deprecated names for environment variables, and the names already warned about.
+var aliases = make(map[string][]string)
+var warned = make(map[string]bool)
+
+// RegisterAlias registers deprecated names for an environment variable, e.g. when REDIS_ADDR is
+// renamed CACHE_ADDR.  If the environment variable isn't set, the getters check each alias in
+// turn, and use the first one that's set, before falling back to the default.  The first time an
+// alias is used, it's passed to the AliasHandler option, if set, to log a warning.  Thread-safe.
+func RegisterAlias(key string, deprecated ...string) {
+	key = normalizeKey(key)
+
+	names := make([]string, len(deprecated))
+	for i, alias := range deprecated {
+		names[i] = normalizeKey(alias)
+	}
+
+	regMutex.Lock()
+	defer regMutex.Unlock()
+
+	aliases[key] = append(aliases[key], names...)
+}
+
+// Look up the environment variable, or the first of its aliases that's set.
+func lookupEnv(key string) (string, bool) {
+	key = normalizeKey(key)
+
+	if val, set := osLookupEnv(key); set {
+		return val, true
+	}
+
+	regMutex.RLock()
+	deprecated := aliases[key]
+	regMutex.RUnlock()
+
+	for _, alias := range deprecated {
+		if val, set := osLookupEnv(alias); set {
+			warnAlias(key, alias)
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// Look up the environment variable.  If the CaseInsensitiveKeys option is set, and it isn't set in
+// upper case, finds it in any case, e.g. "Port".
+func osLookupEnv(key string) (string, bool) {
+	if val, set := os.LookupEnv(key); set || !currentOptions().CaseInsensitiveKeys {
+		return val, set
+	}
+
+	for _, env := range os.Environ() {
+		if name, val, ok := strings.Cut(env, "="); ok && strings.EqualFold(name, key) {
+			return val, true
+		}
+	}
+
+	return "", false
+}
+
+// Pass the alias to the AliasHandler option the first time it's used.
+func warnAlias(key, alias string) {
+	regMutex.Lock()
+	first := !warned[alias]
+	warned[alias] = true
+	regMutex.Unlock()
+
+	if handler := currentOptions().AliasHandler; first && handler != nil {
+		handler(key, alias)
 	}
 }
 
@@ -66,11 +318,14 @@ func Default(key string) (descriptor, bool) {
 	regMutex.RLock()
 	defer regMutex.RUnlock()
 
-	val, present := registered[key]
+	val, present := registered[normalizeKey(key)]
 
 	return val, present
 }
 
+// Displayed in place of a secret value.
+const redacted = "(redacted)"
+
 // Colorized output
 var (
 	keyColor     = color.New(color.FgYellow)
@@ -79,32 +334,71 @@ var (
 	descColor    = color.New(color.FgWhite)
 
 	typeNames = map[int]string{
-		StringType:      "string",
-		StringSliceType: "[]string",
-		IntType:         "integer",
-		Float64Type:     "float",
-		BoolType:        "boolean",
-		DurationType:    "duration",
+		StringType:        "string",
+		StringSliceType:   "[]string",
+		IntType:           "integer",
+		Float64Type:       "float",
+		BoolType:          "boolean",
+		DurationType:      "duration",
+		UintType:          "unsigned",
+		Uint64Type:        "unsigned",
+		TimeType:          "time",
+		URLType:           "url",
+		IPType:            "ip",
+		CIDRType:          "cidr",
+		CIDRSliceType:     "[]cidr",
+		BytesType:         "size",
+		IntSliceType:      "[]integer",
+		Int64SliceType:    "[]integer",
+		Float64SliceType:  "[]float",
+		DurationSliceType: "[]duration",
+		MapType:           "map",
+		BinaryType:        "binary",
+		RegexpType:        "regexp",
+		LogLevelType:      "loglevel",
+		TimeZoneType:      "timezone",
+		PortType:          "port",
+		StringSetType:     "set",
+		IntRangeType:      "range",
+		BigIntType:        "bigint",
+		RatType:           "rational",
+		URLSliceType:      "[]url",
+		IPSliceType:       "[]ip",
+		VersionType:       "version",
+		HeaderType:        "headers",
+		Int64Type:         "integer",
 	}
 )
 
 // Help displays details about registered default variables.  May be called via a `--help`
 // command-line parameter, or if some setting is invalid.  Produces colorized output to stdout.
 func Help() {
-	var keys []string
-	var width, descWidth, defvalWidth int
+	// Copy the descriptors, so the lock isn't held while writing to the terminal.
+	regMutex.RLock()
+	descriptors := make(map[string]descriptor, len(registered))
 	for key, d := range registered {
+		descriptors[key] = d
+	}
+	regMutex.RUnlock()
+
+	var keys []string
+	var width, typeWidth, descWidth, defvalWidth int
+	for key, d := range descriptors {
 		keys = append(keys, key)
 
 		if len(key) > width {
 			width = len(key)
 		}
 
-		if len(d.Description) > descWidth {
-			descWidth = len(d.Description)
+		if w := len(displayType(d)); w > typeWidth {
+			typeWidth = w
+		}
+
+		if w := len(displayDescription(d)); w > descWidth {
+			descWidth = w
 		}
 
-		w := len(fmt.Sprintf("%v", d.DefaultValue))
+		w := len(displayDefault(d))
 		if w > defvalWidth {
 			defvalWidth = w
 		}
@@ -115,26 +409,154 @@ func Help() {
 		termWidth = 80
 	}
 
-	if width+descWidth+defvalWidth+18 > termWidth {
+	if width+typeWidth+descWidth+defvalWidth+6 > termWidth {
 		if defvalWidth > 20 {
 			defvalWidth = 20
 		}
 
-		descWidth = termWidth - width - defvalWidth - 18
+		descWidth = termWidth - width - typeWidth - defvalWidth - 6
 	}
 
 	sort.Strings(keys)
 	for _, key := range keys {
-		d := registered[key]
+		d := descriptors[key]
 
 		_, _ = keyColor.Print(pad(key, width))
 		fmt.Print("  ")
-		_, _ = typeColor.Print(pad(typeNames[d.DataType], 12))
+		_, _ = typeColor.Print(pad(displayType(d), typeWidth))
 		fmt.Print("  ")
-		_, _ = descColor.Print(pad(d.Description, descWidth))
+		_, _ = descColor.Print(pad(displayDescription(d), descWidth))
 		fmt.Print("  ")
-		_, _ = defaultColor.Println(pad(fmt.Sprintf("%v", d.DefaultValue), defvalWidth))
+		_, _ = defaultColor.Println(pad(displayDefault(d), defvalWidth))
+	}
+}
+
+// Name the type for display in Help, including the unit of bare duration values, e.g.
+// "duration(s)".
+func displayType(d descriptor) string {
+	unit := currentOptions().DurationUnit
+	if unit == 0 || (d.DataType != DurationType && d.DataType != DurationSliceType) {
+		return typeNames[d.DataType]
+	}
+
+	abbrev := unit.String()
+	switch unit {
+	case time.Nanosecond:
+		abbrev = "ns"
+	case time.Microsecond:
+		abbrev = "us"
+	case time.Millisecond:
+		abbrev = "ms"
+	case time.Second:
+		abbrev = "s"
+	case time.Minute:
+		abbrev = "m"
+	case time.Hour:
+		abbrev = "h"
+	}
+
+	return typeNames[d.DataType] + "(" + abbrev + ")"
+}
+
+// Describe the variable for display in Help, including any allowed values, e.g.
+// "Log format (json|text|console)", and noting if it has a validator.
+func displayDescription(d descriptor) string {
+	desc := d.Description
+	if len(d.Allowed) > 0 {
+		desc += " (" + strings.Join(d.Allowed, "|") + ")"
+	}
+
+	if d.Validator != nil {
+		desc += " (validated)"
 	}
+
+	return desc
+}
+
+// Format the default value for display in Help, hiding secrets.
+func displayDefault(d descriptor) string {
+	if d.Secret {
+		return redacted
+	}
+
+	return formatDefault(d.DefaultValue)
+}
+
+// Format the default value for display, e.g. times in RFC3339 format.
+func formatDefault(defaultValue interface{}) string {
+	switch v := defaultValue.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []time.Duration:
+		durations := make([]string, len(v))
+		for i, d := range v {
+			durations[i] = d.String()
+		}
+
+		return strings.Join(durations, ",")
+	case []byte:
+		return fmt.Sprintf("(%d bytes)", len(v))
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		entries := make([]string, len(keys))
+		for i, key := range keys {
+			entries[i] = key + "=" + v[key]
+		}
+
+		return strings.Join(entries, ";")
+	case map[string]struct{}:
+		values := make([]string, 0, len(v))
+		for value := range v {
+			values = append(values, value)
+		}
+		sort.Strings(values)
+
+		return strings.Join(values, ",")
+	case *big.Rat:
+		return v.RatString()
+	case []*url.URL:
+		urls := make([]string, len(v))
+		for i, u := range v {
+			urls[i] = u.String()
+		}
+
+		return strings.Join(urls, ",")
+	case http.Header:
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		var entries []string
+		for _, name := range names {
+			for _, value := range v[name] {
+				entries = append(entries, name+": "+value)
+			}
+		}
+
+		return strings.Join(entries, "; ")
+	case []net.IP:
+		ips := make([]string, len(v))
+		for i, ip := range v {
+			ips[i] = ip.String()
+		}
+
+		return strings.Join(ips, ",")
+	case [2]int:
+		if v[0] < 0 || v[1] < 0 {
+			return fmt.Sprintf("%d..%d", v[0], v[1])
+		}
+
+		return fmt.Sprintf("%d-%d", v[0], v[1])
+	}
+
+	return fmt.Sprintf("%v", defaultValue)
 }
 
 func pad(val string, width int) string {
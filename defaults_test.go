@@ -0,0 +1,128 @@
+package dotenv
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Discard anything written to stdout, such as by Help, for the rest of the test.
+func discardStdout(t *testing.T) {
+	t.Helper()
+
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, output := os.Stdout, color.Output
+	os.Stdout, color.Output = devNull, io.Discard
+
+	t.Cleanup(func() {
+		os.Stdout, color.Output = stdout, output
+		_ = devNull.Close()
+	})
+}
+
+// Run with -race to confirm the registry is safe to use from several goroutines.
+func TestRegisterConcurrently(t *testing.T) {
+	discardStdout(t)
+
+	const goroutines = 8
+	const keys = 50
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < keys; i++ {
+				key := fmt.Sprintf("CONCURRENT_%d_%d", g, i)
+
+				switch i % 3 {
+				case 0:
+					Register(key, i, "A number")
+				case 1:
+					RegisterEnum(key, "text", "A format", "json", "text")
+				case 2:
+					RegisterValidated(key, "ok", "Validated", func(string) error { return nil })
+				}
+
+				d, ok := Default(key)
+				if !ok {
+					t.Errorf("expected %s to be registered", key)
+					continue
+				}
+
+				if i%3 == 1 && len(d.Allowed) != 2 {
+					t.Errorf("expected %s to be registered with its allowed values", key)
+				}
+
+				if i%3 == 2 && d.Validator == nil {
+					t.Errorf("expected %s to be registered with its validator", key)
+				}
+
+				if i%3 == 0 && GetInt(key) != i {
+					t.Errorf("expected %s to default to %d; got %d", key, i, GetInt(key))
+				}
+
+				if i%10 == 0 {
+					Help()
+					_ = AllSettings()
+					_ = Validate()
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	if n := len(Keys()); n < goroutines*keys {
+		t.Errorf("expected at least %d keys to be registered; got %d", goroutines*keys, n)
+	}
+}
+
+// Capture what's written to stdout, such as by Help, until the returned function is called.
+func captureStdout(t *testing.T) func() string {
+	t.Helper()
+
+	out, err := os.CreateTemp(t.TempDir(), "stdout")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout, output := os.Stdout, color.Output
+	os.Stdout, color.Output = out, out
+
+	return func() string {
+		os.Stdout, color.Output = stdout, output
+
+		data, err := os.ReadFile(out.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return string(data)
+	}
+}
+
+func TestHelpTypeWidth(t *testing.T) {
+	setOptions(t, Options{DurationUnit: time.Millisecond})
+	Register("HELP_TIMEOUTS", []time.Duration{time.Second}, "Timeouts")
+
+	captured := captureStdout(t)
+	Help()
+	out := captured()
+
+	if !strings.Contains(out, "[]duration(ms)") {
+		t.Errorf("expected the full type to be displayed; got\n%s", out)
+	}
+}
@@ -0,0 +1,91 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LoadDir loads the environment settings from a directory in the daemontools envdir layout, as used
+// by runit and s6 supervised services.  Each regular file in the directory sets the environment
+// variable named after the file, with the contents of the file as the value, less a single
+// trailing newline.  Nested directories are ignored.
+//
+// Files named for invalid environment variables are skipped, but the rest of the directory is still
+// loaded.  The returned error names each file that was skipped.
+func LoadDir(dir string) error {
+	l := newLoader()
+
+	skipped, err := l.processDir(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := l.finish(); err != nil {
+		return err
+	}
+
+	return errors.Join(skipped...)
+}
+
+// Process an envdir directory into environment variables.  Returns the files skipped for invalid
+// names separately, as the rest of the directory is still loaded.
+func (l *loader) processDir(dir string) ([]error, error) {
+	l.files = append(l.files, loadedFile{name: dir, dir: true})
+
+	assignments, skipped, err := parseDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return skipped, l.apply(assignments)
+}
+
+// Read the settings from each file in an envdir directory, in filename order.  Returns the files
+// skipped for invalid names separately, so the rest may still be applied.
+func parseDir(dir string) ([]assignment, []error, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := currentOptions()
+
+	var assignments []assignment
+	var skipped []error
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			if entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+
+			// Kubernetes mounts secrets and config maps as symlinks, so follow them
+			if info, err := os.Stat(filepath.Join(dir, entry.Name())); err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+		}
+
+		filename := filepath.Join(dir, entry.Name())
+
+		key, err := checkKey(entry.Name(), opts)
+		if err != nil {
+			skipped = append(skipped, fmt.Errorf("skipped %s: %w", filename, err))
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value := strings.TrimSuffix(string(data), "\n")
+		value = strings.TrimSuffix(value, "\r")
+
+		assignments = append(assignments, assignment{Key: key, Value: value, File: filename, Line: 1})
+	}
+
+	return assignments, skipped, nil
+}
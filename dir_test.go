@@ -0,0 +1,97 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Create an envdir directory with a file for each setting.
+func writeDir(t *testing.T, settings map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, value := range settings {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return dir
+}
+
+func TestLoadDir(t *testing.T) {
+	unsetenv(t, "DIR_HOST", "DIR_PORT", "DIR_EMPTY")
+
+	dir := writeDir(t, map[string]string{
+		"DIR_HOST":  "example.com\n",
+		"DIR_PORT":  "8080\r\n",
+		"DIR_EMPTY": "",
+		"not-valid": "skipped",
+	})
+
+	err := LoadDir(dir)
+	if err == nil {
+		t.Error("expected the invalid file name to be reported")
+	}
+
+	expected := map[string]string{"DIR_HOST": "example.com", "DIR_PORT": "8080", "DIR_EMPTY": ""}
+	for key, value := range expected {
+		if val, set := os.LookupEnv(key); !set || val != value {
+			t.Errorf("expected %s to be %q; got %q, %v", key, value, val, set)
+		}
+	}
+}
+
+func TestLoadDirFileSecrets(t *testing.T) {
+	setOptions(t, Options{FileSecrets: true})
+	unsetenv(t, "DIR_PASSWORD", "DIR_PASSWORD_FILE")
+
+	// resolving secrets also reads any other _FILE variables in the environment, e.g. SSL_CERT_FILE
+	snapshot := Snapshot()
+	t.Cleanup(func() {
+		if err := snapshot.Restore(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	secret := writeFile(t, "password", "hunter2")
+	dir := writeDir(t, map[string]string{"DIR_PASSWORD_FILE": secret})
+
+	if err := LoadDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("DIR_PASSWORD"); val != "hunter2" {
+		t.Errorf("expected DIR_PASSWORD to be read from the _FILE; got %q", val)
+	}
+}
+
+func TestLoadDirReload(t *testing.T) {
+	unsetenv(t, "DIR_RELOAD_HOST", "DIR_RELOAD_PORT")
+
+	dir := writeDir(t, map[string]string{"DIR_RELOAD_HOST": "a"})
+	if err := LoadDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "DIR_RELOAD_HOST"), []byte("b"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "DIR_RELOAD_PORT"), []byte("80"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	delta, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(delta.Changed) != 1 || len(delta.Added) != 1 {
+		t.Errorf("expected DIR_RELOAD_HOST to change and DIR_RELOAD_PORT to be added; got %+v", delta)
+	}
+
+	if host, port := os.Getenv("DIR_RELOAD_HOST"), os.Getenv("DIR_RELOAD_PORT"); host != "b" || port != "80" {
+		t.Errorf("expected the directory to be reloaded; got DIR_RELOAD_HOST=%q, DIR_RELOAD_PORT=%q", host, port)
+	}
+}
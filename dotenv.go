@@ -13,11 +13,23 @@
 package dotenv
 
 import (
-	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/textproto"
+	"net/url"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -30,41 +42,159 @@ var (
 	// ErrBadLocalFile returned when the local .env file (in the same directory as the app) is
 	// invalid.
 	ErrBadLocalFile = errors.New("unable to parse .env file")
+
+	// ErrBadJSONFile returned when the local .env.json file is invalid.
+	ErrBadJSONFile = errors.New("unable to parse .env.json file")
+
+	// ErrNotSet returned when an environment variable isn't set and has no default.
+	ErrNotSet = errors.New("environment variable not set")
 )
 
+// ParseValueError returned by the error-returning getters, such as GetIntE, when an environment
+// variable is set to a value that isn't valid for the type.
+type ParseValueError struct {
+	Key  string
+	Raw  string
+	Type string
+	Err  error
+}
+
+// Error describes the invalid value, e.g. `PORT is set to "808O", which is not a valid integer`.
+func (e *ParseValueError) Error() string {
+	return fmt.Sprintf("%s is set to %q, which is not a valid %s: %s", e.Key, e.Raw, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying parse error.
+func (e *ParseValueError) Unwrap() error {
+	return e.Err
+}
+
 // Load the environment settings from:
 //
-// * the .env file in the startup directory
 // * the .env file in the user's home directory
+// * the .env file in the startup directory
+// * the .env.<os> file in the startup directory, e.g. .env.darwin, if the OSFile option is set
+// * the .env.json file in the startup directory (see LoadJSON)
 //
-// like they are environment variables.  Any existing environment variables are overwritten.
+// like they are environment variables.  The file names, and whether to load the file in the home
+// directory at all, may be changed with SetOptions.  Any existing environment variables are
+// overwritten, unless the NoOverride option is set.
+//
+// Every file is checked, even if an earlier one is invalid, and the returned error describes all
+// the problems found.  Use errors.Is to check for ErrBadUserFile, ErrBadLocalFile, or
+// ErrBadJSONFile, or errors.As to get at the individual *ParseError values.
 func Load() error {
-	if home, err := os.UserHomeDir(); err == nil {
-		userEnv := path.Join(path.Clean(home), ".env")
-		if exists(userEnv) {
-			if err := process(userEnv); err != nil {
-				return ErrBadUserFile
-			}
+	return load(newLoader())
+}
+
+// Overload loads the environment settings just like Load, but always overwrites any existing
+// environment variables, regardless of the NoOverride option.  Use this when the .env files
+// should override everything.
+func Overload() error {
+	l := newLoader()
+	l.override = true
+
+	return load(l)
+}
+
+// LoadNoOverride loads the environment settings just like Load, but never overwrites environment
+// variables that were already set before loading.  Use this when the .env files simply provide
+// defaults, e.g. in production where the orchestrator's environment variables should win.
+func LoadNoOverride() error {
+	return load(newProtectedLoader())
+}
+
+// Load the default .env files with the loader.
+func load(l *loader) error {
+	var errs []error
+
+	opts := currentOptions()
+
+	if home, err := os.UserHomeDir(); err == nil && !opts.SkipUserFile {
+		userEnv := path.Join(path.Clean(home), opts.userFile())
+		if err := l.loadFile(userEnv, l.process); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrBadUserFile, err))
 		}
 	}
 
-	localEnv := ".env"
-	if exists(localEnv) {
-		if err := process(localEnv); err != nil {
-			return ErrBadLocalFile
+	localEnv := opts.localFile()
+	if opts.SearchParents {
+		localEnv = nearest(localEnv)
+	}
+
+	if err := l.loadFile(localEnv, l.process); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrBadLocalFile, err))
+	}
+
+	if opts.OSFile {
+		if err := l.loadFile(localEnv+"."+runtime.GOOS, l.process); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrBadLocalFile, err))
 		}
 	}
 
-	return nil
+	if err := l.loadFile(localEnv+".json", l.processJSON); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrBadJSONFile, err))
+	}
+
+	if err := l.finish(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
 }
 
 // GetString returns the environment variable as a string value.  If the environment variable
 // doesn't exist, returns the default value if present, otherwise a blank string.
 func GetString(key string) string {
-	if val, set := os.LookupEnv(key); set {
+	if val, set := lookupEnv(key); set {
+		return val
+	}
+
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(string); ok {
+			return defaultValue
+		}
+	}
+
+	return ""
+}
+
+// GetEnum returns the environment variable if it matches one of the allowed values, ignoring case,
+// such as LOG_FORMAT=JSON given "json", "text", and "console".  Returns the value as written in
+// the allowed list.  If the environment variable doesn't exist or isn't allowed, returns the
+// default value if present, otherwise a blank string.  If no values are given, uses those passed
+// to RegisterEnum.
+func GetEnum(key string, allowed ...string) string {
+	if val, err := GetEnumE(key, allowed...); err == nil {
 		return val
 	}
 
+	return defaultString(key)
+}
+
+// GetEnumE returns the environment variable if it matches one of the allowed values, like GetEnum,
+// or a *ParseValueError listing the allowed values if it doesn't.
+func GetEnumE(key string, allowed ...string) (string, error) {
+	if len(allowed) == 0 {
+		descriptor, _ := Default(key)
+		allowed = descriptor.Allowed
+	}
+
+	if val, set := lookupEnv(key); set {
+		for _, value := range allowed {
+			if strings.EqualFold(val, value) {
+				return value, nil
+			}
+		}
+
+		return "", &ParseValueError{Key: key, Raw: val, Type: "enum", Err: fmt.Errorf("must be one of %s", strings.Join(allowed, ", "))}
+	}
+
+	return defaultString(key), nil
+}
+
+// Returns the registered default string, or a blank string.
+func defaultString(key string) string {
 	if descriptor, ok := Default(key); ok {
 		if defaultValue, ok := descriptor.DefaultValue.(string); ok {
 			return defaultValue
@@ -74,15 +204,194 @@ func GetString(key string) string {
 	return ""
 }
 
+// GetBasicAuth returns the environment variable split into a user and password, such as
+// METRICS_AUTH=prometheus:s3cr3t, and true if it contains a colon.  Only the first colon separates
+// the two, so the password may contain colons, and may be blank.  If the environment variable
+// doesn't exist or has no colon, uses the default value if present, otherwise returns false.  Since
+// the value is a credential, register any default with RegisterSecret so Help never displays it.
+func GetBasicAuth(key string) (user, pass string, ok bool) {
+	if val, set := lookupEnv(key); set {
+		if user, pass, ok := strings.Cut(val, ":"); ok {
+			return user, pass, true
+		}
+	}
+
+	if user, pass, ok := strings.Cut(defaultString(key), ":"); ok {
+		return user, pass, true
+	}
+
+	return "", "", false
+}
+
 // GetStringSlice returns the environment variable as a string slice value.  If the environment
 // variable doesn't exist, returns the default value if present, otherwise a nil value.  Expects a
-// environment variable value to be a comma-separated list of values.
+// environment variable value to be a comma-separated list of values.  Whitespace around each value
+// is trimmed, empty values are dropped, and "\," is a comma within a value.
 func GetStringSlice(key string) []string {
-	if val, set := os.LookupEnv(key); set {
-		sliced := strings.Split(val, ",")
+	if sliced, err := GetStringSliceE(key); err == nil {
 		return sliced
 	}
 
+	return defaultStringSlice(key)
+}
+
+// GetStringSliceE returns the environment variable as a string slice value, like GetStringSlice.
+// The error is always nil, as any value is a valid list of strings.
+func GetStringSliceE(key string) ([]string, error) {
+	if val, set := lookupEnv(key); set {
+		return splitList(val, ","), nil
+	}
+
+	return defaultStringSlice(key), nil
+}
+
+// GetStringSliceSep returns the environment variable as a string slice value, like GetStringSlice,
+// but with the values separated by sep, e.g. ";" or "|".  Escape the separator in a value with a
+// backslash.
+func GetStringSliceSep(key, sep string) []string {
+	if val, set := lookupEnv(key); set {
+		return splitList(val, sep)
+	}
+
+	return defaultStringSlice(key)
+}
+
+// GetCSV returns the environment variable parsed as a single CSV record, so values may be quoted to
+// contain commas, e.g. SUBJECTS="Doe, Jane",Smith.  Quotes within a quoted value are doubled, and
+// leading whitespace before each value is trimmed.  If the environment variable doesn't exist or
+// isn't valid CSV, returns the default value if present, otherwise a nil value.
+func GetCSV(key string) []string {
+	if val, set := lookupEnv(key); set {
+		if record, err := parseCSV(val); err == nil {
+			return record
+		}
+	}
+
+	return defaultStringSlice(key)
+}
+
+// Parse the value as a single CSV record.
+func parseCSV(val string) ([]string, error) {
+	if strings.TrimSpace(val) == "" {
+		return nil, nil
+	}
+
+	r := csv.NewReader(strings.NewReader(val))
+	r.TrimLeadingSpace = true
+
+	record, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := r.Read(); err != io.EOF {
+		return nil, errors.New("more than one line")
+	}
+
+	return record, nil
+}
+
+// GetStringSet returns the environment variable as a set of strings, such as an allow-list of
+// client IDs.  Expects a comma-separated list of values, split like GetStringSlice, and ignores any
+// duplicates.  If the environment variable doesn't exist, returns the default value if present,
+// which may be registered as a []string or map[string]struct{}, otherwise a nil value.
+func GetStringSet(key string) map[string]struct{} {
+	return stringSet(key, false)
+}
+
+// GetStringSetFold returns the environment variable as a set of strings, like GetStringSet, but
+// with each value lowercased, so lookups can ignore case.
+func GetStringSetFold(key string) map[string]struct{} {
+	return stringSet(key, true)
+}
+
+// InSet returns true if member is one of the values in the set returned by GetStringSet.
+func InSet(key, member string) bool {
+	_, ok := GetStringSet(key)[member]
+	return ok
+}
+
+// InSetFold returns true if member is one of the values in the set returned by GetStringSet,
+// ignoring case.
+func InSetFold(key, member string) bool {
+	_, ok := GetStringSetFold(key)[strings.ToLower(member)]
+	return ok
+}
+
+// Get the set of strings from the environment variable or default, lowercasing them if fold is set.
+func stringSet(key string, fold bool) map[string]struct{} {
+	if val, set := lookupEnv(key); set {
+		return toSet(splitList(val, ","), fold)
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case []string:
+			return toSet(defaultValue, fold)
+		case map[string]struct{}:
+			if !fold {
+				return defaultValue
+			}
+
+			values := make([]string, 0, len(defaultValue))
+			for value := range defaultValue {
+				values = append(values, value)
+			}
+
+			return toSet(values, fold)
+		}
+	}
+
+	return nil
+}
+
+// Convert the values to a set, lowercasing them if fold is set.
+func toSet(values []string, fold bool) map[string]struct{} {
+	set := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		if fold {
+			value = strings.ToLower(value)
+		}
+
+		set[value] = struct{}{}
+	}
+
+	return set
+}
+
+// Split the list on the separator, except where it's escaped with a backslash, trimming whitespace
+// around each value and dropping any empty values.
+func splitList(val, sep string) []string {
+	var sliced []string
+	var elem strings.Builder
+
+	add := func() {
+		if trimmed := strings.TrimSpace(elem.String()); trimmed != "" {
+			sliced = append(sliced, trimmed)
+		}
+		elem.Reset()
+	}
+
+	for len(val) > 0 {
+		switch {
+		case strings.HasPrefix(val, `\`+sep):
+			elem.WriteString(sep)
+			val = val[1+len(sep):]
+		case strings.HasPrefix(val, sep):
+			add()
+			val = val[len(sep):]
+		default:
+			elem.WriteByte(val[0])
+			val = val[1:]
+		}
+	}
+	add()
+
+	return sliced
+}
+
+// Returns the registered default string slice, or nil.
+func defaultStringSlice(key string) []string {
 	if descriptor, ok := Default(key); ok {
 		if defaultValue, ok := descriptor.DefaultValue.([]string); ok {
 			return defaultValue
@@ -92,156 +401,1392 @@ func GetStringSlice(key string) []string {
 	return nil
 }
 
-// GetInt returns the environment variable as an integer value.  If the environment variable doesn't
-// exist or is not an integer, returns the default value if present, otherwise returns 0.
-func GetInt(key string) int {
-	if val, set := os.LookupEnv(key); set {
-		if ival, err := strconv.Atoi(val); err == nil {
-			return ival
+// GetIntSlice returns the environment variable as a comma-separated list of integers, e.g.
+// "100,500,2000".  If the environment variable doesn't exist or any of the values is not an
+// integer, returns the default value if present, otherwise a nil value.
+func GetIntSlice(key string) []int {
+	if val, set := lookupEnv(key); set {
+		if sliced, err := parseList(val, strconv.Atoi); err == nil {
+			return sliced
 		}
 	}
 
 	if descriptor, ok := Default(key); ok {
-		if defaultValue, ok := descriptor.DefaultValue.(int); ok {
+		if defaultValue, ok := descriptor.DefaultValue.([]int); ok {
 			return defaultValue
 		}
 	}
 
-	return 0
+	return nil
 }
 
-// GetInt64 returns the environment variable as an int64 value.  If the environment variable doesn't
-// exist or is not an int64, returns the default value if present, otherwise returns 0.
-func GetInt64(key string) int64 {
-	if val, set := os.LookupEnv(key); set {
-		if ival, err := strconv.ParseInt(val, 10, 64); err == nil {
-			return ival
+// GetInt64Slice returns the environment variable as a comma-separated list of int64 values.  If the
+// environment variable doesn't exist or any of the values is not an integer, returns the default
+// value if present, otherwise a nil value.
+func GetInt64Slice(key string) []int64 {
+	if val, set := lookupEnv(key); set {
+		if sliced, err := parseList(val, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }); err == nil {
+			return sliced
 		}
 	}
 
 	if descriptor, ok := Default(key); ok {
-		switch defaultValue := descriptor.DefaultValue.(type) {
-		case int:
-			return int64(defaultValue)
-		case int64:
+		if defaultValue, ok := descriptor.DefaultValue.([]int64); ok {
 			return defaultValue
-		default:
-			return 0
 		}
 	}
 
-	return 0
+	return nil
 }
 
-// GetFloat64 returns the environment variable as an float64 value.  If the environment variable
-// doesn't exist, returns the default value if present, otherwise returns 0.
-func GetFloat64(key string) float64 {
-	if val, set := os.LookupEnv(key); set {
-		if fval, err := strconv.ParseFloat(val, 64); err == nil {
-			return fval
+// GetFloat64Slice returns the environment variable as a comma-separated list of float64 values.  If
+// the environment variable doesn't exist or any of the values is not a number, returns the default
+// value if present, otherwise a nil value.
+func GetFloat64Slice(key string) []float64 {
+	if val, set := lookupEnv(key); set {
+		if sliced, err := parseList(val, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) }); err == nil {
+			return sliced
 		}
 	}
 
 	if descriptor, ok := Default(key); ok {
-		if defaultValue, ok := descriptor.DefaultValue.(float64); ok {
+		if defaultValue, ok := descriptor.DefaultValue.([]float64); ok {
 			return defaultValue
 		}
 	}
 
-	return 0
+	return nil
 }
 
-// GetBool returns the environment variable as a boolean value.  If the environment variable doesn't
-// exist, returns the default value if present, otherwise returns false.
-func GetBool(key string) bool {
-	if val, set := os.LookupEnv(key); set {
-		if strings.EqualFold(val, "true") {
-			return true
+// GetDurationSlice returns the environment variable as a comma-separated list of durations, e.g.
+// "1s,5s,30s,2m".  Empty values, such as after a trailing comma, are skipped.  If the environment
+// variable doesn't exist or any of the values is not a duration, returns the default value if
+// present, otherwise a nil value.
+func GetDurationSlice(key string) []time.Duration {
+	if val, set := lookupEnv(key); set {
+		if sliced, err := parseDurations(val); err == nil {
+			return sliced
 		}
 	}
 
 	if descriptor, ok := Default(key); ok {
-		if defaultValue, ok := descriptor.DefaultValue.(bool); ok {
+		if defaultValue, ok := descriptor.DefaultValue.([]time.Duration); ok {
 			return defaultValue
 		}
 	}
 
-	return false
+	return nil
 }
 
-// GetDuration returns the environment variable as an time.Duration value.  If the environment
-// variable doesn't exist, returns the default value if present, otherwise returns 0.
-func GetDuration(key string) time.Duration {
-	if val, set := os.LookupEnv(key); set {
-		if dval, err := time.ParseDuration(val); err == nil {
-			return dval
+// Parse the comma-separated list of durations, skipping any empty values.
+func parseDurations(val string) ([]time.Duration, error) {
+	return parseList(val, func(s string) (time.Duration, error) {
+		if s == "" {
+			return 0, errSkip
+		}
+
+		return parseDuration(s, currentOptions().DurationUnit)
+	})
+}
+
+// Returned by a parse function passed to parseList to skip the value.
+var errSkip = errors.New("skip")
+
+// Parse each of the comma-separated values, trimming any whitespace around them.  Fails if any of
+// the values can't be parsed, as a partial list is worse than none, unless parse returns errSkip to
+// leave the value out.
+func parseList[T any](val string, parse func(string) (T, error)) ([]T, error) {
+	var sliced []T
+
+	for _, elem := range strings.Split(val, ",") {
+		v, err := parse(strings.TrimSpace(elem))
+		if err == errSkip {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		sliced = append(sliced, v)
+	}
+
+	return sliced, nil
+}
+
+// GetStringMap returns the environment variable as a map of keys to values, e.g.
+// "X-Env=staging;X-Team=payments".  Entries are separated by semicolons, and each key is separated
+// from its value by the first equals sign.  Whitespace around the keys and values is trimmed, and
+// empty entries are ignored.  If the environment variable doesn't exist or has an entry without a
+// value, returns the default value if present, otherwise a nil value.
+func GetStringMap(key string) map[string]string {
+	return GetStringMapSep(key, ";", "=")
+}
+
+// GetStringMapSep returns the environment variable as a map of keys to values, like GetStringMap,
+// but with entries separated by sep and each key separated from its value by kvSep, e.g. "," and
+// ":".
+func GetStringMapSep(key, sep, kvSep string) map[string]string {
+	if val, set := lookupEnv(key); set {
+		if mapped, err := parseMap(val, sep, kvSep); err == nil {
+			return mapped
 		}
 	}
 
 	if descriptor, ok := Default(key); ok {
-		if defaultValue, ok := descriptor.DefaultValue.(time.Duration); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(map[string]string); ok {
 			return defaultValue
 		}
 	}
 
-	return 0
+	return nil
 }
 
-func exists(filename string) bool {
-	if info, err := os.Stat(filename); err == nil {
-		if info.IsDir() {
-			return false
+// Parse the entries into a map.
+func parseMap(val, sep, kvSep string) (map[string]string, error) {
+	mapped := make(map[string]string)
+
+	for _, entry := range strings.Split(val, sep) {
+		if strings.TrimSpace(entry) == "" {
+			continue
 		}
 
-		return true
+		k, v, ok := strings.Cut(entry, kvSep)
+		if !ok {
+			return nil, fmt.Errorf("missing %q in %q", kvSep, entry)
+		}
+
+		mapped[strings.TrimSpace(k)] = strings.TrimSpace(v)
 	}
 
-	return false
+	return mapped, nil
 }
 
-// Process a file into environment variables.
-func process(filename string) error {
-	file, err := os.Open(filename)
-	if err != nil {
-		return err
+// GetHeaderMap returns the environment variable as HTTP headers, such as
+// EXTRA_HEADERS="X-Tenant: acme; X-Trace: on".  Headers are separated by semicolons or newlines,
+// and each name is separated from its value by the first colon.  Names are canonicalized, e.g.
+// "x-tenant" is "X-Tenant", and a repeated name adds another value.  If the environment variable
+// doesn't exist or has a header without a name, returns a copy of the default value if present,
+// which may be registered as http.Header or map[string]string, otherwise a nil value.
+func GetHeaderMap(key string) http.Header {
+	if val, set := lookupEnv(key); set {
+		if header, err := parseHeader(val); err == nil {
+			return header
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case http.Header:
+			return defaultValue.Clone()
+		case map[string]string:
+			header := make(http.Header, len(defaultValue))
+			for name, value := range defaultValue {
+				header.Add(name, value)
+			}
+
+			return header
+		}
 	}
-	defer file.Close()
 
-	s := bufio.NewScanner(file)
-	lineNo := -1
+	return nil
+}
 
-	for s.Scan() {
-		line := s.Text()
-		lineNo++
+// Parse the headers, separated by semicolons or newlines.
+func parseHeader(val string) (http.Header, error) {
+	header := make(http.Header)
 
-		if commentIdx := strings.Index(line, "#"); commentIdx == 0 {
+	for _, entry := range strings.FieldsFunc(val, func(c rune) bool { return c == ';' || c == '\n' }) {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
 			continue
-		} else if commentIdx != -1 {
-			line = line[0:commentIdx]
 		}
 
-		if strings.TrimSpace(line) == "" {
-			continue
+		name, value, ok := strings.Cut(entry, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q", entry)
 		}
 
-		parts := strings.SplitN(line, "=", 2)
-		if len(parts) != 2 {
-			// rather than error out, simply skip this line...
-			// return fmt.Errorf("unable to parse line %s:%d", filename, lineNo)
-			continue
+		header.Add(textproto.CanonicalMIMEHeaderKey(name), strings.TrimSpace(value))
+	}
+
+	return header, nil
+}
+
+// GetInt returns the environment variable as an integer value.  If the environment variable doesn't
+// exist or is not an integer, returns the default value if present, otherwise returns 0.
+func GetInt(key string) int {
+	if ival, err := GetIntE(key); err == nil {
+		return ival
+	}
+
+	return defaultInt(key)
+}
+
+// GetIntE returns the environment variable as an integer value, like GetInt, but returns a
+// *ParseValueError if the environment variable is set but is not an integer, rather than falling
+// back to the default.
+func GetIntE(key string) (int, error) {
+	if val, set := lookupEnv(key); set {
+		ival, err := strconv.Atoi(val)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: typeNames[IntType], Err: err}
+		}
+
+		return ival, nil
+	}
+
+	return defaultInt(key), nil
+}
+
+// Returns the registered default integer, or 0.
+func defaultInt(key string) int {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(int); ok {
+			return defaultValue
 		}
+	}
 
-		key := strings.TrimSpace(parts[0])
-		value := strings.TrimSpace(parts[1])
+	return 0
+}
 
-		if key == "" || value == "" {
-			return fmt.Errorf("invalid environment variable assignment %s:%d", filename, lineNo)
+// GetIntRange returns the environment variable as an inclusive range of integers, such as
+// WORKER_PORTS=9000-9049, and true if it's a valid range.  With a "-" separator, both numbers must
+// be non-negative, so "-5-5" is invalid; use ".." for negative numbers, e.g. "-5..5".  Whitespace
+// around the numbers is ignored, and lo must not be greater than hi.  If the environment variable
+// doesn't exist or isn't a valid range, uses the default value if present, which may be registered
+// as a string or [2]int, otherwise returns 0, 0, and false.
+func GetIntRange(key string) (lo, hi int, ok bool) {
+	if val, set := lookupEnv(key); set {
+		if lo, hi, err := parseIntRange(val); err == nil {
+			return lo, hi, true
 		}
+	}
 
-		if err := os.Setenv(key, value); err != nil {
-			return fmt.Errorf("failed to assign %s value %s (%s:%d)", key, value, filename, lineNo)
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case [2]int:
+			if defaultValue[0] <= defaultValue[1] {
+				return defaultValue[0], defaultValue[1], true
+			}
+		case string:
+			if lo, hi, err := parseIntRange(defaultValue); err == nil {
+				return lo, hi, true
+			}
 		}
 	}
 
-	return nil
+	return 0, 0, false
+}
+
+// Parse the range, either "lo-hi" with non-negative numbers, or "lo..hi".
+func parseIntRange(val string) (int, int, error) {
+	sep := ".."
+	if !strings.Contains(val, sep) {
+		sep = "-"
+	}
+
+	parts := strings.Split(val, sep)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("%q is not a range", val)
+	}
+
+	lo, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	hi, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if lo > hi {
+		return 0, 0, fmt.Errorf("%d is greater than %d", lo, hi)
+	}
+
+	return lo, hi, nil
+}
+
+// GetInt64 returns the environment variable as an int64 value.  If the environment variable doesn't
+// exist or is not an int64, returns the default value if present, otherwise returns 0.
+func GetInt64(key string) int64 {
+	if val, set := lookupEnv(key); set {
+		if ival, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return ival
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case int:
+			return int64(defaultValue)
+		case int64:
+			return defaultValue
+		default:
+			return 0
+		}
+	}
+
+	return 0
+}
+
+// GetUint returns the environment variable as an unsigned integer value.  If the environment
+// variable doesn't exist or is not an unsigned integer, e.g. it's negative, returns the default
+// value if present, otherwise returns 0.
+func GetUint(key string) uint {
+	if val, set := lookupEnv(key); set {
+		if uval, err := strconv.ParseUint(val, 10, strconv.IntSize); err == nil {
+			return uint(uval)
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case int:
+			if defaultValue >= 0 {
+				return uint(defaultValue)
+			}
+		case int64:
+			if defaultValue >= 0 && uint64(defaultValue) <= math.MaxUint {
+				return uint(defaultValue)
+			}
+		case uint:
+			return defaultValue
+		case uint64:
+			if defaultValue <= math.MaxUint {
+				return uint(defaultValue)
+			}
+		}
+	}
+
+	return 0
+}
+
+// GetUint64 returns the environment variable as a uint64 value.  If the environment variable
+// doesn't exist or is not an unsigned integer, e.g. it's negative, returns the default value if
+// present, otherwise returns 0.
+func GetUint64(key string) uint64 {
+	if val, set := lookupEnv(key); set {
+		if uval, err := strconv.ParseUint(val, 10, 64); err == nil {
+			return uval
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case int:
+			if defaultValue >= 0 {
+				return uint64(defaultValue)
+			}
+		case int64:
+			if defaultValue >= 0 {
+				return uint64(defaultValue)
+			}
+		case uint:
+			return uint64(defaultValue)
+		case uint64:
+			return defaultValue
+		}
+	}
+
+	return 0
+}
+
+// GetBytes returns the environment variable as a number of bytes, such as 25MB or 1.5GiB.  The
+// decimal (KB, MB, GB, TB) and binary (KiB, MiB, GiB, TiB) suffixes are case-insensitive, and a
+// bare number is a number of bytes.  If the environment variable doesn't exist or is not a size,
+// returns the default value if present, which may be registered as a ByteSize, int, or int64,
+// otherwise returns 0.
+func GetBytes(key string) int64 {
+	if val, set := lookupEnv(key); set {
+		if bval, err := parseBytes(val); err == nil {
+			return bval
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case ByteSize:
+			return int64(defaultValue)
+		case int:
+			return int64(defaultValue)
+		case int64:
+			return defaultValue
+		}
+	}
+
+	return 0
+}
+
+// GetFloat64 returns the environment variable as an float64 value.  If the environment variable
+// doesn't exist, returns the default value if present, otherwise returns 0.
+func GetFloat64(key string) float64 {
+	if fval, err := GetFloat64E(key); err == nil {
+		return fval
+	}
+
+	return defaultFloat64(key)
+}
+
+// GetFloat64E returns the environment variable as a float64 value, like GetFloat64, but returns a
+// *ParseValueError if the environment variable is set but is not a number, rather than falling back
+// to the default.
+func GetFloat64E(key string) (float64, error) {
+	if val, set := lookupEnv(key); set {
+		fval, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: typeNames[Float64Type], Err: err}
+		}
+
+		return fval, nil
+	}
+
+	return defaultFloat64(key), nil
+}
+
+// Returns the registered default float64, or 0.
+func defaultFloat64(key string) float64 {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(float64); ok {
+			return defaultValue
+		}
+	}
+
+	return 0
+}
+
+// GetPercent returns the environment variable as a fraction between 0 and 1, such as
+// CPU_TARGET=75%.  A value with a trailing "%", or greater than 1, is a percentage and divided by
+// 100, so "75%", "75", and "0.75" all return 0.75.  Note "1" is 100%, while "1%" is 0.01.
+// Negative values and values over 100% are invalid.  If the environment variable doesn't exist or
+// is invalid, returns the default value if present, registered as a float64 fraction, otherwise 0.
+func GetPercent(key string) float64 {
+	if pct, err := GetPercentE(key); err == nil {
+		return pct
+	}
+
+	return defaultFloat64(key)
+}
+
+// GetPercentE returns the environment variable as a fraction between 0 and 1, like GetPercent, but
+// returns a *ParseValueError if the environment variable is set to anything other than a valid
+// percentage, such as the typo "750%", rather than falling back to the default.
+func GetPercentE(key string) (float64, error) {
+	if val, set := lookupEnv(key); set {
+		pct, err := parsePercent(val)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: "percentage", Err: err}
+		}
+
+		return pct, nil
+	}
+
+	return defaultFloat64(key), nil
+}
+
+// Parse the percentage into a fraction between 0 and 1.
+func parsePercent(val string) (float64, error) {
+	val = strings.TrimSpace(val)
+
+	num := strings.TrimSuffix(val, "%")
+	pct, err := strconv.ParseFloat(strings.TrimSpace(num), 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if num != val || pct > 1 {
+		pct /= 100
+	}
+
+	switch {
+	case pct < 0:
+		return 0, errors.New("must not be negative")
+	case pct > 1:
+		return 0, errors.New("must not be over 100%")
+	}
+
+	return pct, nil
+}
+
+// GetBool returns the environment variable as a boolean value.  If the environment variable doesn't
+// exist, returns the default value if present, otherwise returns false.
+func GetBool(key string) bool {
+	if bval, err := GetBoolE(key); err == nil {
+		return bval
+	}
+
+	return defaultBool(key)
+}
+
+// GetBoolE returns the environment variable as a boolean value, like GetBool, but returns a
+// *ParseValueError if the environment variable is set to anything other than "true" or "false", in
+// any case, rather than falling back to the default.
+func GetBoolE(key string) (bool, error) {
+	if val, set := lookupEnv(key); set {
+		switch {
+		case strings.EqualFold(val, "true"):
+			return true, nil
+		case strings.EqualFold(val, "false"):
+			return false, nil
+		}
+
+		return false, &ParseValueError{Key: key, Raw: val, Type: typeNames[BoolType], Err: errors.New(`must be "true" or "false"`)}
+	}
+
+	return defaultBool(key), nil
+}
+
+// Returns the registered default boolean, or false.
+func defaultBool(key string) bool {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(bool); ok {
+			return defaultValue
+		}
+	}
+
+	return false
+}
+
+// GetDuration returns the environment variable as an time.Duration value.  If the environment
+// variable doesn't exist, returns the default value if present, otherwise returns 0.
+func GetDuration(key string) time.Duration {
+	if dval, err := GetDurationE(key); err == nil {
+		return dval
+	}
+
+	return defaultDuration(key)
+}
+
+// GetDurationE returns the environment variable as a time.Duration value, like GetDuration, but
+// returns a *ParseValueError if the environment variable is set but is not a duration, rather than
+// falling back to the default.
+func GetDurationE(key string) (time.Duration, error) {
+	if val, set := lookupEnv(key); set {
+		dval, err := parseDuration(val, currentOptions().DurationUnit)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: typeNames[DurationType], Err: err}
+		}
+
+		return dval, nil
+	}
+
+	return defaultDuration(key), nil
+}
+
+// GetDurationDefaultUnit returns the environment variable as a time.Duration value, like
+// GetDuration, but treats a bare number without a unit, such as "30", as a number of units, e.g.
+// time.Second, regardless of the DurationUnit option.
+func GetDurationDefaultUnit(key string, unit time.Duration) time.Duration {
+	if val, set := lookupEnv(key); set {
+		if dval, err := parseDuration(val, unit); err == nil {
+			return dval
+		}
+	}
+
+	return defaultDuration(key)
+}
+
+// Parse the duration.  If unit isn't 0, a bare number is a number of units, so "30" is 30 seconds
+// given time.Second.
+func parseDuration(val string, unit time.Duration) (time.Duration, error) {
+	dval, err := time.ParseDuration(val)
+	if err == nil || unit == 0 {
+		return dval, err
+	}
+
+	if n, err := strconv.ParseFloat(val, 64); err == nil {
+		return time.Duration(n * float64(unit)), nil
+	}
+
+	return 0, err
+}
+
+// Returns the registered default duration, or 0.
+func defaultDuration(key string) time.Duration {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(time.Duration); ok {
+			return defaultValue
+		}
+	}
+
+	return 0
+}
+
+// Layouts tried by GetTime after RFC3339, in order.
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02"}
+
+// GetTime returns the environment variable as a time.Time value.  The value may be in RFC3339 or
+// RFC3339Nano format, a date such as 2025-12-01 (UTC), or an integer number of seconds since the
+// Unix epoch.  If the environment variable doesn't exist or is not a time, returns the default
+// value if present, otherwise returns the zero time.
+func GetTime(key string) time.Time {
+	if val, set := lookupEnv(key); set {
+		if tval, err := parseTime(val); err == nil {
+			return tval
+		}
+	}
+
+	return defaultTime(key)
+}
+
+// Parse the time in any of the formats accepted by GetTime.
+func parseTime(val string) (time.Time, error) {
+	tval, err := time.Parse(time.RFC3339, val)
+	if err == nil {
+		return tval, nil
+	}
+
+	for _, layout := range timeLayouts {
+		if tval, err := time.Parse(layout, val); err == nil {
+			return tval, nil
+		}
+	}
+
+	if secs, err := strconv.ParseInt(val, 10, 64); err == nil {
+		return time.Unix(secs, 0).UTC(), nil
+	}
+
+	return time.Time{}, err
+}
+
+// GetTimeLayout returns the environment variable as a time.Time value, parsed with the layout (see
+// time.Parse).  If the environment variable doesn't exist or doesn't match the layout, returns the
+// default value if present, otherwise returns the zero time.
+func GetTimeLayout(key, layout string) time.Time {
+	if val, set := lookupEnv(key); set {
+		if tval, err := time.Parse(layout, val); err == nil {
+			return tval
+		}
+	}
+
+	return defaultTime(key)
+}
+
+// Returns the registered default time, or the zero time.
+func defaultTime(key string) time.Time {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(time.Time); ok {
+			return defaultValue
+		}
+	}
+
+	return time.Time{}
+}
+
+// GetTimeZone returns the environment variable as a time zone, such as REPORT_TZ=America/Denver.
+// Accepts the IANA zone names, "Local", "UTC", and fixed offsets from UTC such as "+05:30" or
+// "-0700".  If the environment variable doesn't exist or isn't a known zone, returns the default
+// value if present, which may be registered as a string or *time.Location, otherwise time.UTC.
+func GetTimeZone(key string) *time.Location {
+	if loc, err := GetTimeZoneE(key); err == nil {
+		return loc
+	}
+
+	return defaultTimeZone(key)
+}
+
+// GetTimeZoneE returns the environment variable as a time zone, like GetTimeZone, but returns a
+// *ParseValueError wrapping the time.LoadLocation error if the environment variable is set to an
+// unknown zone, rather than falling back to the default.
+func GetTimeZoneE(key string) (*time.Location, error) {
+	if val, set := lookupEnv(key); set {
+		loc, err := parseTimeZone(val)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: typeNames[TimeZoneType], Err: err}
+		}
+
+		return loc, nil
+	}
+
+	return defaultTimeZone(key), nil
+}
+
+// Matches a fixed offset from UTC, e.g. "+05:30", "-0700", or "+02".
+var offsetPattern = regexp.MustCompile(`^([+-])(\d{2}):?(\d{2})?$`)
+
+// Load the named time zone, or a fixed offset from UTC.
+func parseTimeZone(val string) (*time.Location, error) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return nil, errors.New("blank time zone")
+	}
+
+	if m := offsetPattern.FindStringSubmatch(val); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes := 0
+		if m[3] != "" {
+			minutes, _ = strconv.Atoi(m[3])
+		}
+
+		if hours > 14 || minutes > 59 {
+			return nil, fmt.Errorf("invalid offset %q", val)
+		}
+
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+
+		return time.FixedZone(val, offset), nil
+	}
+
+	return time.LoadLocation(val)
+}
+
+// Returns the registered default time zone, loading it if it's a string, or time.UTC.
+func defaultTimeZone(key string) *time.Location {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *time.Location:
+			return defaultValue
+		case string:
+			if loc, err := parseTimeZone(defaultValue); err == nil {
+				return loc
+			}
+		}
+	}
+
+	return time.UTC
+}
+
+// GetURL returns the environment variable as an absolute URL, with a scheme and host.  If the
+// environment variable doesn't exist or is not an absolute URL, returns the default value if
+// present, which may be registered as a string or *url.URL, otherwise returns nil.
+func GetURL(key string) *url.URL {
+	if u, err := GetURLE(key); err == nil {
+		return u
+	}
+
+	return defaultURL(key)
+}
+
+// GetURLE returns the environment variable as an absolute URL, like GetURL, but returns a
+// *ParseValueError if the environment variable is set to anything other than an absolute URL,
+// rather than falling back to the default.
+func GetURLE(key string) (*url.URL, error) {
+	if val, set := lookupEnv(key); set {
+		u, err := parseURL(val)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: typeNames[URLType], Err: err}
+		}
+
+		return u, nil
+	}
+
+	return defaultURL(key), nil
+}
+
+// Parse the value as an absolute URL.
+func parseURL(val string) (*url.URL, error) {
+	u, err := url.Parse(val)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("%q is not an absolute URL", val)
+	}
+
+	return u, nil
+}
+
+// Returns a copy of the registered default URL, or nil.
+func defaultURL(key string) *url.URL {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *url.URL:
+			if defaultValue != nil {
+				u := *defaultValue
+				return &u
+			}
+		case string:
+			if u, err := parseURL(defaultValue); err == nil {
+				return u
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetIP returns the environment variable as an IP address, IPv4 or IPv6.  If the environment
+// variable doesn't exist or is not an IP address, returns the default value if present, which may
+// be registered as a string or net.IP, otherwise returns nil.
+func GetIP(key string) net.IP {
+	if val, set := lookupEnv(key); set {
+		if ip := net.ParseIP(val); ip != nil {
+			return ip
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case net.IP:
+			return defaultValue
+		case string:
+			return net.ParseIP(defaultValue)
+		}
+	}
+
+	return nil
+}
+
+// GetCIDR returns the environment variable as an IP network in CIDR notation, e.g. 10.0.0.0/8.  If
+// the environment variable doesn't exist or is not a network, returns the default value if present,
+// which may be registered as a string or *net.IPNet, otherwise returns nil.
+func GetCIDR(key string) *net.IPNet {
+	if val, set := lookupEnv(key); set {
+		if _, network, err := net.ParseCIDR(val); err == nil {
+			return network
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *net.IPNet:
+			return defaultValue
+		case string:
+			if _, network, err := net.ParseCIDR(defaultValue); err == nil {
+				return network
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetCIDRSlice returns the environment variable as a comma-separated list of IP networks, e.g.
+// "10.0.0.0/8,192.168.0.0/16".  If the environment variable doesn't exist, or any of the networks
+// is invalid, returns the default value if present, which may be registered as a string or
+// []*net.IPNet, otherwise returns nil.
+func GetCIDRSlice(key string) []*net.IPNet {
+	if val, set := lookupEnv(key); set {
+		if networks, err := parseCIDRs(val); err == nil {
+			return networks
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case []*net.IPNet:
+			return defaultValue
+		case string:
+			if networks, err := parseCIDRs(defaultValue); err == nil {
+				return networks
+			}
+		}
+	}
+
+	return nil
+}
+
+// Parse the comma-separated list of networks, failing if any are invalid.
+func parseCIDRs(val string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+
+	for _, cidr := range strings.Split(val, ",") {
+		_, network, err := net.ParseCIDR(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+// GetURLSlice returns the environment variable as a comma-separated list of absolute URLs, each
+// with a scheme and host, like GetURL.  If the environment variable doesn't exist, or any of the
+// URLs is invalid, returns the default value if present, which may be registered as a
+// []*url.URL or, with RegisterURLSlice, a []string, otherwise returns nil.
+func GetURLSlice(key string) []*url.URL {
+	if urls, err := GetURLSliceE(key); err == nil {
+		return urls
+	}
+
+	return defaultURLSlice(key)
+}
+
+// GetURLSliceE returns the environment variable as a comma-separated list of absolute URLs, like
+// GetURLSlice, but returns a *ParseValueError naming the first invalid URL, by its index in the
+// list, rather than falling back to the default.
+func GetURLSliceE(key string) ([]*url.URL, error) {
+	if val, set := lookupEnv(key); set {
+		urls, err := parseElements(val, parseURL)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: typeNames[URLSliceType], Err: err}
+		}
+
+		return urls, nil
+	}
+
+	return defaultURLSlice(key), nil
+}
+
+// Returns copies of the registered default URLs, or nil.
+func defaultURLSlice(key string) []*url.URL {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.([]*url.URL); ok {
+			urls := make([]*url.URL, len(defaultValue))
+			for i, u := range defaultValue {
+				copied := *u
+				urls[i] = &copied
+			}
+
+			return urls
+		}
+	}
+
+	return nil
+}
+
+// GetIPSlice returns the environment variable as a comma-separated list of IP addresses, IPv4 or
+// IPv6.  If the environment variable doesn't exist, or any of the addresses is invalid, returns the
+// default value if present, which may be registered as a []net.IP or, with RegisterIPSlice, a
+// []string, otherwise returns nil.
+func GetIPSlice(key string) []net.IP {
+	if ips, err := GetIPSliceE(key); err == nil {
+		return ips
+	}
+
+	return defaultIPSlice(key)
+}
+
+// GetIPSliceE returns the environment variable as a comma-separated list of IP addresses, like
+// GetIPSlice, but returns a *ParseValueError naming the first invalid address, by its index in the
+// list, rather than falling back to the default.
+func GetIPSliceE(key string) ([]net.IP, error) {
+	if val, set := lookupEnv(key); set {
+		ips, err := parseElements(val, parseIP)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: typeNames[IPSliceType], Err: err}
+		}
+
+		return ips, nil
+	}
+
+	return defaultIPSlice(key), nil
+}
+
+// Returns the registered default IP addresses, or nil.
+func defaultIPSlice(key string) []net.IP {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.([]net.IP); ok {
+			return defaultValue
+		}
+	}
+
+	return nil
+}
+
+// Parse the IP address.
+func parseIP(val string) (net.IP, error) {
+	ip := net.ParseIP(val)
+	if ip == nil {
+		return nil, fmt.Errorf("%q is not an IP address", val)
+	}
+
+	return ip, nil
+}
+
+// Parse each of the comma-separated values, trimming whitespace and skipping empty values.  Fails
+// if any of the values can't be parsed, naming it by its index in the list.
+func parseElements[T any](val string, parse func(string) (T, error)) ([]T, error) {
+	var parsed []T
+
+	for i, elem := range strings.Split(val, ",") {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			continue
+		}
+
+		v, err := parse(elem)
+		if err != nil {
+			return nil, fmt.Errorf("element %d: %w", i, err)
+		}
+
+		parsed = append(parsed, v)
+	}
+
+	return parsed, nil
+}
+
+// GetHostPort returns the environment variable split into a host and port, such as
+// LISTEN=0.0.0.0:8080, and true if it's a valid address.  IPv6 hosts must be in brackets, e.g.
+// "[::1]:8080", and the host may be blank, e.g. ":8080".  The port must be between 0 and 65535.
+// If the environment variable doesn't exist or isn't a valid address, uses the default value if
+// present and valid, otherwise returns a blank host, 0, and false.
+func GetHostPort(key string) (host string, port int, ok bool) {
+	if val, set := lookupEnv(key); set {
+		if host, port, err := parseHostPort(val); err == nil {
+			return host, port, true
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(string); ok {
+			if host, port, err := parseHostPort(defaultValue); err == nil {
+				return host, port, true
+			}
+		}
+	}
+
+	return "", 0, false
+}
+
+// GetHostPortSlice returns the environment variable as a comma-separated list of addresses, such
+// as PEERS=10.0.0.1:7000,10.0.0.2:7000, checking each one like GetHostPort.  If the environment
+// variable doesn't exist, or any of the addresses is invalid, returns the default value if present
+// and valid, which may be registered as a string or []string, otherwise returns nil.
+func GetHostPortSlice(key string) []string {
+	if val, set := lookupEnv(key); set {
+		if addrs, err := parseHostPorts(splitList(val, ",")); err == nil {
+			return addrs
+		}
+	}
+
+	if descriptor, ok := Default(key); ok {
+		var addrs []string
+		var err error
+
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case []string:
+			addrs, err = parseHostPorts(defaultValue)
+		case string:
+			addrs, err = parseHostPorts(splitList(defaultValue, ","))
+		}
+
+		if err == nil {
+			return addrs
+		}
+	}
+
+	return nil
+}
+
+// Split the address into a host and port, checking the port is in range.
+func parseHostPort(val string) (string, int, error) {
+	host, portStr, err := net.SplitHostPort(strings.TrimSpace(val))
+	if err != nil {
+		return "", 0, err
+	}
+
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid port %q", portStr)
+	}
+
+	return host, int(port), nil
+}
+
+// Check each of the addresses, failing if any is invalid.
+func parseHostPorts(addrs []string) ([]string, error) {
+	for _, addr := range addrs {
+		if _, _, err := parseHostPort(addr); err != nil {
+			return nil, err
+		}
+	}
+
+	return addrs, nil
+}
+
+// GetBase64 returns the environment variable decoded from base64, such as a signing key.  Both the
+// standard and URL-safe alphabets are accepted, with or without padding.  If the environment
+// variable doesn't exist or can't be decoded, returns the default value if present, which may be
+// registered as a base64 string or []byte, otherwise returns nil.
+func GetBase64(key string) []byte {
+	if data, err := GetBase64E(key); err == nil {
+		return data
+	}
+
+	return defaultBinary(key, decodeBase64)
+}
+
+// GetBase64E returns the environment variable decoded from base64, like GetBase64, but returns a
+// *ParseValueError if the environment variable is set but can't be decoded, e.g. a truncated
+// secret, rather than falling back to the default.
+func GetBase64E(key string) ([]byte, error) {
+	if val, set := lookupEnv(key); set {
+		data, err := decodeBase64(val)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: "base64", Err: err}
+		}
+
+		return data, nil
+	}
+
+	return defaultBinary(key, decodeBase64), nil
+}
+
+// GetHex returns the environment variable decoded from hexadecimal.  If the environment variable
+// doesn't exist or can't be decoded, returns the default value if present, which may be registered
+// as a hex string or []byte, otherwise returns nil.
+func GetHex(key string) []byte {
+	if data, err := GetHexE(key); err == nil {
+		return data
+	}
+
+	return defaultBinary(key, hex.DecodeString)
+}
+
+// GetHexE returns the environment variable decoded from hexadecimal, like GetHex, but returns a
+// *ParseValueError if the environment variable is set but can't be decoded, rather than falling
+// back to the default.
+func GetHexE(key string) ([]byte, error) {
+	if val, set := lookupEnv(key); set {
+		data, err := hex.DecodeString(val)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: "hex", Err: err}
+		}
+
+		return data, nil
+	}
+
+	return defaultBinary(key, hex.DecodeString), nil
+}
+
+// Decode the value with whichever base64 encoding it uses.
+func decodeBase64(val string) ([]byte, error) {
+	encoding := base64.StdEncoding
+	if strings.ContainsAny(val, "-_") {
+		encoding = base64.URLEncoding
+	}
+
+	if !strings.HasSuffix(val, "=") {
+		encoding = encoding.WithPadding(base64.NoPadding)
+	}
+
+	return encoding.DecodeString(val)
+}
+
+// Returns the registered default, decoding it if it's a string, or nil.
+func defaultBinary(key string, decode func(string) ([]byte, error)) []byte {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case []byte:
+			return defaultValue
+		case string:
+			if data, err := decode(defaultValue); err == nil {
+				return data
+			}
+		}
+	}
+
+	return nil
+}
+
+// The largest file GetFileContents reads by default.
+const defaultFileContentsMaxSize = 10 << 20
+
+// GetFileContents treats the environment variable as a path, such as TLS_CERT=/etc/ssl/app.pem, and
+// returns the contents of the file.  If the environment variable doesn't exist, uses the default
+// path if present.  Returns ErrNotSet if there's no path, an error wrapping fs.ErrNotExist if the
+// file is missing, or an error naming the key and path if the file can't be read or is larger than
+// the FileContentsMaxSize option, 10MB by default.
+func GetFileContents(key string) ([]byte, error) {
+	filename := GetString(key)
+	if filename == "" {
+		return nil, fmt.Errorf("%s: %w", key, ErrNotSet)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s for %s: %w", filename, key, err)
+	}
+	defer file.Close()
+
+	maxSize := currentOptions().fileContentsMaxSize()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s for %s: %w", filename, key, err)
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("unable to read %s for %s: larger than %d bytes", filename, key, maxSize)
+	}
+
+	return data, nil
+}
+
+// GetExistingPath returns the environment variable as the absolute path to an existing file or
+// directory, such as CA_BUNDLE=~/certs/ca.pem.  A leading "~" is replaced by the user's home
+// directory, and relative paths are resolved against the working directory.  If the environment
+// variable doesn't exist, uses the default path if present.  Returns ErrNotSet if there's no path,
+// or an error naming the key and the resolved path if it can't be found.
+func GetExistingPath(key string) (string, error) {
+	filename, _, err := statPath(key)
+	return filename, err
+}
+
+// GetDir returns the environment variable as the absolute path to an existing directory, such as
+// TEMPLATE_DIR=./templates, like GetExistingPath, but also fails if the path isn't a directory.
+func GetDir(key string) (string, error) {
+	filename, info, err := statPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s for %s is not a directory", filename, key)
+	}
+
+	return filename, nil
+}
+
+// Resolve the path in the environment variable and check it exists.
+func statPath(key string) (string, os.FileInfo, error) {
+	raw := GetString(key)
+	if raw == "" {
+		return "", nil, fmt.Errorf("%s: %w", key, ErrNotSet)
+	}
+
+	filename, err := resolvePath(raw)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to resolve %s for %s: %w", raw, key, err)
+	}
+
+	info, err := os.Stat(filename)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to find %s for %s: %w", filename, key, err)
+	}
+
+	return filename, info, nil
+}
+
+// Expand a leading "~" to the user's home directory, and make the path absolute.
+func resolvePath(filename string) (string, error) {
+	if filename == "~" || strings.HasPrefix(filename, "~/") || strings.HasPrefix(filename, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return filename, err
+		}
+
+		filename = filepath.Join(home, filename[1:])
+	}
+
+	return filepath.Abs(filename)
+}
+
+// GetRegexp returns the environment variable compiled as a regular expression.  If the environment
+// variable doesn't exist or doesn't compile, returns the default value if present, which may be
+// registered as a pattern string or *regexp.Regexp, otherwise returns nil.
+func GetRegexp(key string) *regexp.Regexp {
+	if re, err := GetRegexpE(key); err == nil {
+		return re
+	}
+
+	return defaultRegexp(key)
+}
+
+// GetRegexpE returns the environment variable compiled as a regular expression, like GetRegexp, but
+// returns a *ParseValueError wrapping the compile error if the environment variable is set to an
+// invalid pattern, rather than falling back to the default.
+func GetRegexpE(key string) (*regexp.Regexp, error) {
+	if val, set := lookupEnv(key); set {
+		re, err := regexp.Compile(val)
+		if err != nil {
+			return nil, &ParseValueError{Key: key, Raw: val, Type: typeNames[RegexpType], Err: err}
+		}
+
+		return re, nil
+	}
+
+	return defaultRegexp(key), nil
+}
+
+// Returns the registered default regular expression, compiling it if it's a string, or nil.
+func defaultRegexp(key string) *regexp.Regexp {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case *regexp.Regexp:
+			return defaultValue
+		case string:
+			if re, err := regexp.Compile(defaultValue); err == nil {
+				return re
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetJSON unmarshals the environment variable, a JSON document such as
+// {"search":true,"limits":{"rps":50}}, into target.  If the environment variable doesn't exist,
+// unmarshals the default value if it's a string.  Returns an error naming the key if the JSON is
+// invalid, or ErrNotSet if there's no value.
+func GetJSON(key string, target interface{}) error {
+	val, set := lookupEnv(key)
+	if !set {
+		descriptor, ok := Default(key)
+		if !ok {
+			return fmt.Errorf("%s: %w", key, ErrNotSet)
+		}
+
+		if val, ok = descriptor.DefaultValue.(string); !ok {
+			return fmt.Errorf("%s: %w", key, ErrNotSet)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(val), target); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// MustGetJSON unmarshals the environment variable into target, like GetJSON, but panics if it
+// can't, e.g. for configuration the application can't start without.
+func MustGetJSON(key string, target interface{}) {
+	if err := GetJSON(key, target); err != nil {
+		panic(err)
+	}
+}
+
+// Look for the file in the current directory, then each parent directory in turn, stopping at the
+// root of the project, i.e. a directory containing a go.mod file or .git directory.  Returns the
+// path to the first match, or the filename unchanged if there isn't one.
+func nearest(filename string) string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return filename
+	}
+
+	for {
+		if candidate := filepath.Join(dir, filename); exists(candidate) {
+			return candidate
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			break
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return filename
+}
+
+func exists(filename string) bool {
+	if info, err := os.Stat(filename); err == nil {
+		if info.IsDir() {
+			return false
+		}
+
+		return true
+	}
+
+	return false
 }
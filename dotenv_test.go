@@ -0,0 +1,346 @@
+package dotenv
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetJSON(t *testing.T) {
+	type matrix struct {
+		Search bool `json:"search"`
+		Limits struct {
+			RPS int `json:"rps"`
+		} `json:"limits"`
+	}
+
+	unsetenv(t, "JSON_MISSING", "JSON_DEFAULT", "JSON_NOT_STRING")
+	t.Setenv("JSON_MATRIX", `{"search":true,"limits":{"rps":50}}`)
+	t.Setenv("JSON_INVALID", `{"search":`)
+
+	Register("JSON_DEFAULT", `{"limits":{"rps":10}}`, "A JSON default")
+	Register("JSON_NOT_STRING", 10, "Not a JSON default")
+
+	var m matrix
+	if err := GetJSON("JSON_MATRIX", &m); err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Search || m.Limits.RPS != 50 {
+		t.Errorf("expected the JSON to be unmarshalled; got %+v", m)
+	}
+
+	m = matrix{}
+	if err := GetJSON("JSON_DEFAULT", &m); err != nil {
+		t.Errorf("expected the default to be unmarshalled; got %s", err)
+	} else if m.Limits.RPS != 10 {
+		t.Errorf("expected the default's rps of 10; got %+v", m)
+	}
+
+	for _, key := range []string{"JSON_MISSING", "JSON_NOT_STRING"} {
+		if err := GetJSON(key, &m); !errors.Is(err, ErrNotSet) {
+			t.Errorf("expected %s to be reported as not set; got %v", key, err)
+		}
+	}
+
+	err := GetJSON("JSON_INVALID", &m)
+	if err == nil || !strings.Contains(err.Error(), "JSON_INVALID") {
+		t.Errorf("expected invalid JSON to be reported, naming the key; got %v", err)
+	}
+}
+
+func TestMustGetJSON(t *testing.T) {
+	unsetenv(t, "JSON_MUST_MISSING")
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected MustGetJSON to panic when the variable isn't set")
+		}
+	}()
+
+	var v map[string]interface{}
+	MustGetJSON("JSON_MUST_MISSING", &v)
+}
+
+func TestGetDurationUnit(t *testing.T) {
+	tests := []struct {
+		val      string
+		unit     time.Duration
+		expected time.Duration
+	}{
+		{val: "30", expected: time.Minute},
+		{val: "30s", expected: 30 * time.Second},
+		{val: "30", unit: time.Second, expected: 30 * time.Second},
+		{val: "1.5", unit: time.Second, expected: 1500 * time.Millisecond},
+		{val: "250", unit: time.Millisecond, expected: 250 * time.Millisecond},
+		{val: "500ms", unit: time.Second, expected: 500 * time.Millisecond},
+		{val: "thirty", unit: time.Second, expected: time.Minute},
+	}
+
+	setOptions(t, Options{})
+	Register("DURATION_TIMEOUT", time.Minute, "Timeout")
+
+	for _, test := range tests {
+		t.Setenv("DURATION_TIMEOUT", test.val)
+		SetOptions(Options{DurationUnit: test.unit})
+
+		if d := GetDuration("DURATION_TIMEOUT"); d != test.expected {
+			t.Errorf("%q with unit %s: expected %s; got %s", test.val, test.unit, test.expected, d)
+		}
+	}
+}
+
+func TestGetDurationDefaultUnit(t *testing.T) {
+	setOptions(t, Options{DurationUnit: time.Second})
+	t.Setenv("DURATION_INTERVAL", "5")
+
+	if d := GetDurationDefaultUnit("DURATION_INTERVAL", time.Minute); d != 5*time.Minute {
+		t.Errorf("expected the unit given to override the option; got %s", d)
+	}
+}
+
+func TestDurationHelpType(t *testing.T) {
+	d := descriptor{DataType: DurationType}
+
+	if name := displayType(d); name != "duration" {
+		t.Errorf("expected no unit without the DurationUnit option; got %q", name)
+	}
+
+	setOptions(t, Options{DurationUnit: time.Second})
+
+	if name := displayType(d); name != "duration(s)" {
+		t.Errorf("expected the unit to be displayed; got %q", name)
+	}
+
+	d.DataType = DurationSliceType
+	if name := displayType(d); !strings.HasSuffix(name, "(s)") {
+		t.Errorf("expected the unit to be displayed for duration slices; got %q", name)
+	}
+}
+
+func TestParseIntRange(t *testing.T) {
+	tests := []struct {
+		val     string
+		lo, hi  int
+		invalid bool
+	}{
+		{val: "9000-9049", lo: 9000, hi: 9049},
+		{val: " 9000 - 9049 ", lo: 9000, hi: 9049},
+		{val: "5-5", lo: 5, hi: 5},
+		{val: "0-0", lo: 0, hi: 0},
+		{val: "-5..5", lo: -5, hi: 5},
+		{val: "-10..-1", lo: -10, hi: -1},
+		{val: " -3 .. 3 ", lo: -3, hi: 3},
+		{val: "1..2", lo: 1, hi: 2},
+
+		// the "-" separator is ambiguous with negative numbers, so only ".." supports them
+		{val: "-5-5", invalid: true},
+		{val: "5--3", invalid: true},
+		{val: "-5", invalid: true},
+		{val: "-1-", invalid: true},
+
+		{val: "9049-9000", invalid: true},
+		{val: "5..-1", invalid: true},
+		{val: "1..2..3", invalid: true},
+		{val: "1-2-3", invalid: true},
+		{val: "1-", invalid: true},
+		{val: "a-b", invalid: true},
+		{val: "42", invalid: true},
+		{val: "", invalid: true},
+	}
+
+	for _, test := range tests {
+		lo, hi, err := parseIntRange(test.val)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("%q: expected an error; got %d, %d", test.val, lo, hi)
+			}
+
+			continue
+		}
+
+		if err != nil || lo != test.lo || hi != test.hi {
+			t.Errorf("%q: expected %d, %d; got %d, %d, %v", test.val, test.lo, test.hi, lo, hi, err)
+		}
+	}
+}
+
+func TestGetIntRangeDefault(t *testing.T) {
+	unsetenv(t, "RANGE_UNSET", "RANGE_ARRAY", "RANGE_STRING", "RANGE_REVERSED")
+	t.Setenv("RANGE_INVALID", "-5-5")
+
+	Register("RANGE_ARRAY", [2]int{1, 4}, "A [2]int range")
+	Register("RANGE_STRING", "10-20", "A string range")
+	Register("RANGE_REVERSED", [2]int{4, 1}, "An invalid range")
+	Register("RANGE_INVALID", "-5..5", "Invalid in the environment")
+
+	tests := []struct {
+		key    string
+		lo, hi int
+		ok     bool
+	}{
+		{key: "RANGE_UNSET"},
+		{key: "RANGE_ARRAY", lo: 1, hi: 4, ok: true},
+		{key: "RANGE_STRING", lo: 10, hi: 20, ok: true},
+		{key: "RANGE_REVERSED"},
+		{key: "RANGE_INVALID", lo: -5, hi: 5, ok: true},
+	}
+
+	for _, test := range tests {
+		lo, hi, ok := GetIntRange(test.key)
+		if lo != test.lo || hi != test.hi || ok != test.ok {
+			t.Errorf("%s: expected %d, %d, %v; got %d, %d, %v", test.key, test.lo, test.hi, test.ok, lo, hi, ok)
+		}
+	}
+}
+
+func TestGetUintDefault(t *testing.T) {
+	unsetenv(t, "UINT_INT", "UINT_INT64", "UINT_NEGATIVE", "UINT_NEGATIVE_INT64")
+
+	Register("UINT_INT", 10, "An int")
+	Register("UINT_INT64", int64(20), "An int64")
+	Register("UINT_NEGATIVE", -1, "A negative int")
+	Register("UINT_NEGATIVE_INT64", int64(-1), "A negative int64")
+
+	tests := []struct {
+		key      string
+		expected uint64
+	}{
+		{key: "UINT_INT", expected: 10},
+		{key: "UINT_INT64", expected: 20},
+		{key: "UINT_NEGATIVE"},
+		{key: "UINT_NEGATIVE_INT64"},
+	}
+
+	for _, test := range tests {
+		if val := GetUint(test.key); uint64(val) != test.expected {
+			t.Errorf("GetUint(%s): expected %d; got %d", test.key, test.expected, val)
+		}
+
+		if val := GetUint64(test.key); val != test.expected {
+			t.Errorf("GetUint64(%s): expected %d; got %d", test.key, test.expected, val)
+		}
+	}
+}
+
+func TestGetE(t *testing.T) {
+	tests := []struct {
+		name         string
+		get          func(key string) (interface{}, error)
+		defaultValue interface{}
+		valid        string
+		expected     interface{}
+		invalid      string
+		typeName     string
+	}{
+		{
+			name:         "GetIntE",
+			get:          func(key string) (interface{}, error) { return GetIntE(key) },
+			defaultValue: 10,
+			valid:        "8080",
+			expected:     8080,
+			invalid:      "808O",
+			typeName:     "integer",
+		},
+		{
+			name:         "GetFloat64E",
+			get:          func(key string) (interface{}, error) { return GetFloat64E(key) },
+			defaultValue: 0.5,
+			valid:        "2.5",
+			expected:     2.5,
+			invalid:      "two",
+			typeName:     "float",
+		},
+		{
+			name:         "GetBoolE",
+			get:          func(key string) (interface{}, error) { return GetBoolE(key) },
+			defaultValue: true,
+			valid:        "FALSE",
+			expected:     false,
+			invalid:      "yes",
+			typeName:     "boolean",
+		},
+		{
+			name:         "GetDurationE",
+			get:          func(key string) (interface{}, error) { return GetDurationE(key) },
+			defaultValue: time.Minute,
+			valid:        "90s",
+			expected:     90 * time.Second,
+			invalid:      "soon",
+			typeName:     "duration",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key := "GET_E_" + strings.ToUpper(test.typeName)
+			Register(key, test.defaultValue, "A value for the E getters")
+
+			unsetenv(t, key)
+			if val, err := test.get(key); err != nil || !reflect.DeepEqual(val, test.defaultValue) {
+				t.Errorf("expected the default %v when unset; got %v, %v", test.defaultValue, val, err)
+			}
+
+			t.Setenv(key, test.valid)
+			if val, err := test.get(key); err != nil || !reflect.DeepEqual(val, test.expected) {
+				t.Errorf("%q: expected %v; got %v, %v", test.valid, test.expected, val, err)
+			}
+
+			t.Setenv(key, test.invalid)
+			_, err := test.get(key)
+
+			var perr *ParseValueError
+			if !errors.As(err, &perr) {
+				t.Fatalf("%q: expected a *ParseValueError; got %v", test.invalid, err)
+			}
+
+			if perr.Key != key || perr.Raw != test.invalid || perr.Type != test.typeName || perr.Err == nil {
+				t.Errorf("%q: expected the error to describe the key, value, and type; got %+v", test.invalid, perr)
+			}
+		})
+	}
+}
+
+func TestGetStringSliceE(t *testing.T) {
+	unsetenv(t, "GET_E_SLICE")
+	Register("GET_E_SLICE", []string{"a"}, "A list for GetStringSliceE")
+
+	if val, err := GetStringSliceE("GET_E_SLICE"); err != nil || !reflect.DeepEqual(val, []string{"a"}) {
+		t.Errorf("expected the default when unset; got %v, %v", val, err)
+	}
+
+	t.Setenv("GET_E_SLICE", `b, c\,d,,`)
+	if val, err := GetStringSliceE("GET_E_SLICE"); err != nil || !reflect.DeepEqual(val, []string{"b", "c,d"}) {
+		t.Errorf("expected the list to be split; got %v, %v", val, err)
+	}
+
+	t.Setenv("GET_E_SLICE", "")
+	if val, err := GetStringSliceE("GET_E_SLICE"); err != nil || len(val) != 0 {
+		t.Errorf("expected a blank value to be an empty list, never an error; got %v, %v", val, err)
+	}
+}
+
+func TestParseValueError(t *testing.T) {
+	t.Setenv("GET_E_PORT", "808O")
+
+	_, err := GetIntE("GET_E_PORT")
+	if err == nil {
+		t.Fatal("expected an invalid integer to fail")
+	}
+
+	if msg := err.Error(); !strings.HasPrefix(msg, `GET_E_PORT is set to "808O", which is not a valid integer: `) {
+		t.Errorf("expected the message to describe the value; got %q", msg)
+	}
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Errorf("expected the parse error to be unwrapped; got %T", errors.Unwrap(err))
+	}
+
+	if val := GetInt("GET_E_PORT"); val != 0 {
+		t.Errorf("expected GetInt to fall back to 0; got %d", val)
+	}
+}
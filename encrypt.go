@@ -0,0 +1,107 @@
+package dotenv
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// The size of the key required for AES-256.
+const keySize = 32
+
+// ErrDecrypt returned by LoadEncrypted when the file can't be decrypted, i.e. the key is wrong or
+// the file is corrupted.
+var ErrDecrypt = errors.New("unable to decrypt file")
+
+// LoadEncrypted loads the environment settings from a file encrypted with EncryptFile, such as a
+// .env.enc file committed to source control, just like LoadFiles.  The key must be 32 bytes, for
+// AES-256-GCM, e.g. decoded from a DOTENV_KEY environment variable.  The decrypted settings are
+// parsed in memory and never written to disk.
+//
+// Returns ErrDecrypt if the key is wrong or the file has been corrupted.
+func LoadEncrypted(filename string, key []byte) error {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", filename, err)
+	}
+
+	plaintext, err := decrypt(data, key)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", filename, err)
+	}
+
+	l := newLoader()
+
+	assignments, err := parseWith(osFiles, l.lookup, bytes.NewReader(plaintext), filename)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", filename, err)
+	}
+
+	if err := l.apply(assignments); err != nil {
+		return err
+	}
+
+	return l.finish()
+}
+
+// EncryptFile encrypts the .env file at plainPath with AES-256-GCM, for LoadEncrypted, and writes
+// it to encPath.  The key must be 32 bytes.  A random nonce is generated each time and prefixed
+// to the encrypted file.
+func EncryptFile(plainPath, encPath string, key []byte) error {
+	plaintext, err := os.ReadFile(plainPath)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("unable to generate nonce: %w", err)
+	}
+
+	return os.WriteFile(encPath, gcm.Seal(nonce, nonce, plaintext, nil), 0o600)
+}
+
+// Decrypt the data, expecting the nonce to be prefixed to the ciphertext.
+func decrypt(data, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, ErrDecrypt
+	}
+
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plaintext, nil
+}
+
+// Create the AES-256-GCM cipher for the key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key; must be %d bytes, not %d", keySize, len(key))
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
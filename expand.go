@@ -0,0 +1,73 @@
+package dotenv
+
+import (
+	"fmt"
+	"os"
+)
+
+// How deeply GetStringExpanded follows references to variables that reference other variables.
+const maxExpandDepth = 10
+
+// GetStringExpanded returns the environment variable, like GetString, with any references to other
+// variables, e.g. LOG_PATH=/var/log/${APP_NAME}/app.log, expanded at the time it's read.  Unlike
+// references in a .env file, expanded when the file is loaded, the referenced variables may be set
+// later by another part of the application.  See GetStringExpandedE for the rules.  If the
+// references can't be expanded, returns the value as is.
+func GetStringExpanded(key string) string {
+	if val, err := GetStringExpandedE(key); err == nil {
+		return val
+	}
+
+	return GetString(key)
+}
+
+// GetStringExpandedE returns the environment variable with any references to other variables
+// expanded, like GetStringExpanded.  References may be written $VAR or ${VAR}, and use the current
+// environment, falling back to the registered default, or a blank string.  References in the
+// values of the referenced variables are expanded in turn, and "$$" is a literal dollar sign.
+// Returns an error naming the key if the references are nested more than ten deep, such as when
+// two variables reference each other.
+func GetStringExpandedE(key string) (string, error) {
+	val, err := expandValue(GetString(key), 0)
+	if err != nil {
+		return "", fmt.Errorf("unable to expand %s: %w", key, err)
+	}
+
+	return val, nil
+}
+
+// Expand the references in the value, depth references deep.
+func expandValue(val string, depth int) (string, error) {
+	if depth > maxExpandDepth {
+		return "", fmt.Errorf("references nested more than %d deep", maxExpandDepth)
+	}
+
+	var err error
+	expanded := os.Expand(val, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+
+		ref, e := expandValue(referencedValue(name), depth+1)
+		if e != nil && err == nil {
+			err = e
+		}
+
+		return ref
+	})
+
+	return expanded, err
+}
+
+// Get the value of a referenced variable, or its registered default.
+func referencedValue(name string) string {
+	if val, set := lookupEnv(name); set {
+		return val
+	}
+
+	if descriptor, ok := Default(name); ok {
+		return formatDefault(descriptor.DefaultValue)
+	}
+
+	return ""
+}
@@ -0,0 +1,52 @@
+package dotenv
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetStringExpanded(t *testing.T) {
+	t.Setenv("EXPANDED_APP", "billing")
+	t.Setenv("EXPANDED_DIR", "/var/log/${EXPANDED_APP}")
+	unsetenv(t, "EXPANDED_VALUE", "EXPANDED_MISSING", "EXPANDED_REGION")
+	Register("EXPANDED_REGION", "us-east-1", "A default referenced by another variable")
+
+	tests := []struct {
+		val      string
+		expected string
+	}{
+		{val: "${EXPANDED_DIR}/app.log", expected: "/var/log/billing/app.log"},
+		{val: "$EXPANDED_APP.log", expected: "billing.log"},
+		{val: "s3://${EXPANDED_REGION}", expected: "s3://us-east-1"},
+		{val: "x${EXPANDED_MISSING}y", expected: "xy"},
+		{val: "$$5", expected: "$5"},
+		{val: "plain", expected: "plain"},
+	}
+
+	for _, test := range tests {
+		t.Setenv("EXPANDED_VALUE", test.val)
+
+		if val, err := GetStringExpandedE("EXPANDED_VALUE"); err != nil || val != test.expected {
+			t.Errorf("%q: expected %q; got %q, %v", test.val, test.expected, val, err)
+		}
+	}
+
+	unsetenv(t, "EXPANDED_VALUE")
+	if val := GetStringExpanded("EXPANDED_VALUE"); val != "" {
+		t.Errorf("expected a blank string when unset; got %q", val)
+	}
+}
+
+func TestGetStringExpandedCycle(t *testing.T) {
+	t.Setenv("EXPANDED_A", "${EXPANDED_B}")
+	t.Setenv("EXPANDED_B", "${EXPANDED_A}")
+
+	_, err := GetStringExpandedE("EXPANDED_A")
+	if err == nil || !strings.Contains(err.Error(), "unable to expand EXPANDED_A") {
+		t.Errorf("expected the cycle to be reported, naming the key; got %v", err)
+	}
+
+	if val := GetStringExpanded("EXPANDED_A"); val != "${EXPANDED_B}" {
+		t.Errorf("expected the value as is when it can't be expanded; got %q", val)
+	}
+}
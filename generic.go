@@ -0,0 +1,147 @@
+package dotenv
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// Value lists the types supported by Get and GetOr.  Binary values aren't included, as the
+// encoding is ambiguous; use GetBase64 or GetHex.
+type Value interface {
+	string | []string | int | int64 | uint | uint64 | float64 | bool | time.Duration |
+		time.Time | *url.URL | net.IP | *net.IPNet | []*net.IPNet | ByteSize | []int | []int64 |
+		[]float64 | []time.Duration | map[string]string | *regexp.Regexp | slog.Level |
+		*time.Location
+}
+
+// Get returns the environment variable as the type T, following the same rules as the typed
+// getter, e.g. Get[int]("PORT") is the same as GetInt("PORT") and Get[time.Duration]("TIMEOUT")
+// the same as GetDuration("TIMEOUT").  An int64 is parsed as an integer; use Get[ByteSize] for a
+// size such as "10MB".
+func Get[T Value](key string) T {
+	var v T
+
+	switch p := any(&v).(type) {
+	case *string:
+		*p = GetString(key)
+	case *[]string:
+		*p = GetStringSlice(key)
+	case *int:
+		*p = GetInt(key)
+	case *int64:
+		*p = GetInt64(key)
+	case *uint:
+		*p = GetUint(key)
+	case *uint64:
+		*p = GetUint64(key)
+	case *float64:
+		*p = GetFloat64(key)
+	case *bool:
+		*p = GetBool(key)
+	case *time.Duration:
+		*p = GetDuration(key)
+	case *time.Time:
+		*p = GetTime(key)
+	case **url.URL:
+		*p = GetURL(key)
+	case *net.IP:
+		*p = GetIP(key)
+	case **net.IPNet:
+		*p = GetCIDR(key)
+	case *[]*net.IPNet:
+		*p = GetCIDRSlice(key)
+	case *ByteSize:
+		*p = ByteSize(GetBytes(key))
+	case *[]int:
+		*p = GetIntSlice(key)
+	case *[]int64:
+		*p = GetInt64Slice(key)
+	case *[]float64:
+		*p = GetFloat64Slice(key)
+	case *[]time.Duration:
+		*p = GetDurationSlice(key)
+	case *map[string]string:
+		*p = GetStringMap(key)
+	case **regexp.Regexp:
+		*p = GetRegexp(key)
+	case *slog.Level:
+		*p = GetLogLevel(key)
+	case **time.Location:
+		*p = GetTimeZone(key)
+	default:
+		panic(fmt.Sprintf("dotenv: unsupported type %T for %s", v, key))
+	}
+
+	return v
+}
+
+// GetOr returns the environment variable as the type T, like Get, but returns fallback if it isn't
+// set or its value isn't valid for the type.  Registered defaults are ignored, like the Lookup
+// functions, so there's no need to call Register for a one-off setting.
+func GetOr[T Value](key string, fallback T) T {
+	var v T
+	var ok bool
+
+	switch p := any(&v).(type) {
+	case *string:
+		*p, ok = LookupString(key)
+	case *[]string:
+		*p, ok = LookupStringSlice(key)
+	case *int:
+		*p, ok = LookupInt(key)
+	case *int64:
+		*p, ok = LookupInt64(key)
+	case *uint:
+		*p, ok = LookupUint(key)
+	case *uint64:
+		*p, ok = LookupUint64(key)
+	case *float64:
+		*p, ok = LookupFloat64(key)
+	case *bool:
+		*p, ok = LookupBool(key)
+	case *time.Duration:
+		*p, ok = LookupDuration(key)
+	case *time.Time:
+		*p, ok = LookupTime(key)
+	case **url.URL:
+		*p, ok = LookupURL(key)
+	case *net.IP:
+		*p, ok = LookupIP(key)
+	case **net.IPNet:
+		*p, ok = LookupCIDR(key)
+	case *[]*net.IPNet:
+		*p, ok = LookupCIDRSlice(key)
+	case *ByteSize:
+		var size int64
+		size, ok = LookupBytes(key)
+		*p = ByteSize(size)
+	case *[]int:
+		*p, ok = LookupIntSlice(key)
+	case *[]int64:
+		*p, ok = LookupInt64Slice(key)
+	case *[]float64:
+		*p, ok = LookupFloat64Slice(key)
+	case *[]time.Duration:
+		*p, ok = LookupDurationSlice(key)
+	case *map[string]string:
+		*p, ok = LookupStringMap(key)
+	case **regexp.Regexp:
+		*p, ok = LookupRegexp(key)
+	case *slog.Level:
+		*p, ok = LookupLogLevel(key)
+	case **time.Location:
+		*p, ok = LookupTimeZone(key)
+	default:
+		panic(fmt.Sprintf("dotenv: unsupported type %T for %s", v, key))
+	}
+
+	if !ok {
+		return fallback
+	}
+
+	return v
+}
@@ -0,0 +1,92 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	unsetenv(t, "GENERIC_PORT", "GENERIC_TIMEOUT", "GENERIC_SIZE", "GENERIC_HOSTS")
+	Register("GENERIC_PORT", 8080, "The port for Get")
+	Register("GENERIC_TIMEOUT", 30*time.Second, "The timeout for Get")
+
+	if port := Get[int]("GENERIC_PORT"); port != 8080 {
+		t.Errorf("expected the default port when unset; got %d", port)
+	}
+
+	if timeout := Get[time.Duration]("GENERIC_TIMEOUT"); timeout != 30*time.Second {
+		t.Errorf("expected the default timeout when unset; got %s", timeout)
+	}
+
+	if hosts := Get[[]string]("GENERIC_HOSTS"); hosts != nil {
+		t.Errorf("expected no hosts when unset; got %v", hosts)
+	}
+
+	t.Setenv("GENERIC_PORT", "9090")
+	t.Setenv("GENERIC_TIMEOUT", "1m")
+	t.Setenv("GENERIC_SIZE", "10MB")
+	t.Setenv("GENERIC_HOSTS", "a,b")
+
+	if port := Get[int]("GENERIC_PORT"); port != 9090 {
+		t.Errorf("expected GENERIC_PORT; got %d", port)
+	}
+
+	if timeout := Get[time.Duration]("GENERIC_TIMEOUT"); timeout != time.Minute {
+		t.Errorf("expected GENERIC_TIMEOUT; got %s", timeout)
+	}
+
+	if size := Get[ByteSize]("GENERIC_SIZE"); size != 10_000_000 {
+		t.Errorf("expected GENERIC_SIZE to be parsed like GetBytes; got %d", size)
+	}
+
+	if hosts := Get[[]string]("GENERIC_HOSTS"); !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("expected GENERIC_HOSTS; got %v", hosts)
+	}
+
+	t.Setenv("GENERIC_PORT", "none")
+	t.Setenv("GENERIC_TIMEOUT", "soon")
+
+	if port := Get[int]("GENERIC_PORT"); port != 8080 {
+		t.Errorf("expected the default port when invalid; got %d", port)
+	}
+
+	if timeout := Get[time.Duration]("GENERIC_TIMEOUT"); timeout != 30*time.Second {
+		t.Errorf("expected the default timeout when invalid; got %s", timeout)
+	}
+}
+
+func TestGetOr(t *testing.T) {
+	unsetenv(t, "GENERIC_OR_PORT", "GENERIC_OR_DEBUG")
+	Register("GENERIC_OR_PORT", 8080, "Ignored by GetOr")
+
+	if port := GetOr("GENERIC_OR_PORT", 3000); port != 3000 {
+		t.Errorf("expected the fallback, not the default, when unset; got %d", port)
+	}
+
+	if debug := GetOr("GENERIC_OR_DEBUG", true); !debug {
+		t.Error("expected the fallback when unset")
+	}
+
+	t.Setenv("GENERIC_OR_PORT", "9090")
+	t.Setenv("GENERIC_OR_DEBUG", "false")
+
+	if port := GetOr("GENERIC_OR_PORT", 3000); port != 9090 {
+		t.Errorf("expected GENERIC_OR_PORT; got %d", port)
+	}
+
+	if debug := GetOr("GENERIC_OR_DEBUG", true); debug {
+		t.Error("expected GENERIC_OR_DEBUG to be false")
+	}
+
+	t.Setenv("GENERIC_OR_PORT", "none")
+	t.Setenv("GENERIC_OR_DEBUG", "maybe")
+
+	if port := GetOr("GENERIC_OR_PORT", 3000); port != 3000 {
+		t.Errorf("expected the fallback when invalid; got %d", port)
+	}
+
+	if debug := GetOr("GENERIC_OR_DEBUG", true); !debug {
+		t.Error("expected the fallback when invalid")
+	}
+}
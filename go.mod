@@ -1,8 +1,14 @@
 module github.com/sbowman/dotenv
 
-go 1.14
+go 1.21
 
 require (
 	github.com/fatih/color v1.9.0
 	golang.org/x/crypto v0.0.0-20200728195943-123391ffb6de
 )
+
+require (
+	github.com/mattn/go-colorable v0.1.4 // indirect
+	github.com/mattn/go-isatty v0.0.11 // indirect
+	golang.org/x/sys v0.0.0-20191026070338-33540a1f6037 // indirect
+)
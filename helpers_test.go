@@ -0,0 +1,95 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Write the contents to a file in a temporary directory, returning its path.
+func writeFile(t *testing.T, name, contents string) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return filename
+}
+
+// Unset the environment variables for the test, restoring them afterwards.
+func unsetenv(t *testing.T, keys ...string) {
+	t.Helper()
+
+	for _, key := range keys {
+		t.Setenv(key, "")
+		if err := os.Unsetenv(key); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Change to the directory for the test, such as a temporary directory holding a .env file for Load,
+// changing back afterwards.
+func chdir(t *testing.T, dir string) {
+	t.Helper()
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		if err := os.Chdir(wd); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// Set the options for the test, restoring the defaults afterwards.
+func setOptions(t *testing.T, opts Options) {
+	t.Helper()
+
+	SetOptions(opts)
+	t.Cleanup(func() { SetOptions(Options{}) })
+}
+
+// A table test for Parse: the settings expected from the contents, or a fragment of the error.
+type parseTest struct {
+	name     string
+	contents string
+	expected map[string]string
+	err      string
+}
+
+// Parse the contents of each test, checking the settings or the error match.
+func runParseTests(t *testing.T, tests []parseTest) {
+	t.Helper()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings, err := Parse(strings.NewReader(test.contents))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Errorf("expected an error containing %q; got %v", test.err, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(settings, test.expected) {
+				t.Errorf("expected %v; got %v", test.expected, settings)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package dotenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// LoadJSON loads the environment settings from a JSON file containing a flat object, e.g.
+// `{"PORT": 8080, "DEBUG": true}`.  Strings are assigned as is, while numbers and booleans are
+// converted to their string form.  Nested objects, arrays, and nulls are rejected with an error
+// rather than flattened, as there's no unambiguous way to name the resulting variables.
+func LoadJSON(filename string) error {
+	return newLoader().processJSON(filename)
+}
+
+// Parse the flat JSON object in the file into assignments.  JSON objects have no line numbers, so
+// the assignments are in key order and the line is always 0.
+func parseJSON(filename string) ([]assignment, error) {
+	data, err := readFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings map[string]interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&settings); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", filename, err)
+	}
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	opts := currentOptions()
+	assignments := make([]assignment, 0, len(keys))
+
+	for _, name := range keys {
+		key, err := checkKey(name, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s in %s", err, filename)
+		}
+
+		var value string
+
+		switch v := settings[name].(type) {
+		case string:
+			value = v
+		case json.Number:
+			value = v.String()
+		case bool:
+			value = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("unsupported value for %s in %s; must be a string, number, or boolean", key, filename)
+		}
+
+		assignments = append(assignments, assignment{Key: key, Value: value, File: filename})
+	}
+
+	return assignments, nil
+}
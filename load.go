@@ -0,0 +1,457 @@
+package dotenv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// The filename that reads the settings from stdin.
+const stdinName = "-"
+
+// Applies parsed settings to the environment.  Unless overriding, environment variables that were
+// set before loading started are left alone.
+//
+// If set is configured, the settings are passed to it instead.  For a dry run, the changes are
+// recorded rather than applied.  Otherwise the prior values of the variables are recorded, so the
+// load may be undone by Unload.  In every case, the values are remembered so later files see them,
+// and so Reload can tell what changed.  If env is configured, variables the files haven't assigned
+// are looked up with it rather than in the environment.
+type loader struct {
+	override bool
+	existing map[string]bool
+	filter   func(key string) bool
+	prefix   string
+	stdin    bool
+	report   *Report
+	files    []loadedFile
+	set      func(key, value string) error
+	env      func(key string) (string, bool)
+
+	dryRun  bool
+	changes []Change
+	vars    map[string]string
+	removed map[string]bool
+	origins map[string]assignment
+
+	layer    *layer
+	recorded map[string]bool
+}
+
+// Create a loader for a single load operation, overriding existing environment variables unless
+// the NoOverride option is set, and skipping any keys rejected by the KeyFilter option.
+func newLoader() *loader {
+	opts := currentOptions()
+
+	l := &loader{override: true}
+	if opts.NoOverride {
+		l = newProtectedLoader()
+	}
+	l.filter = opts.KeyFilter
+
+	return l
+}
+
+// Create a loader that leaves the variables currently set in the environment alone.
+func newProtectedLoader() *loader {
+	l := &loader{existing: make(map[string]bool)}
+	for _, env := range os.Environ() {
+		l.existing[strings.SplitN(env, "=", 2)[0]] = true
+	}
+
+	return l
+}
+
+// LoadFiles loads the environment settings from each of the files, in order, so settings in later
+// files override those in earlier ones.  Unlike Load, every file must exist.  Every file is
+// checked, even if an earlier one is invalid, and the returned error names each file that failed
+// and why.
+//
+// The filename "-" reads the settings from stdin, such as settings decrypted with
+// "sops -d env.enc | ./app".  Stdin is only read once, and must not be a terminal.
+func LoadFiles(filenames ...string) error {
+	return newLoader().processFiles(filenames)
+}
+
+// LoadGlob loads the settings from each of the files matching the pattern, such as
+// "conf.d/*.env", in lexical order, so settings in later files override those in earlier ones.
+// See filepath.Match for the pattern syntax.  Directories are skipped.  Unless the AllowEmptyGlob
+// option is set, it's an error if no files match.
+func LoadGlob(pattern string) error {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+	}
+
+	sort.Strings(matches)
+
+	var filenames []string
+	for _, match := range matches {
+		if exists(match) {
+			filenames = append(filenames, match)
+		}
+	}
+
+	if len(filenames) == 0 && !currentOptions().AllowEmptyGlob {
+		return fmt.Errorf("no files match %s", pattern)
+	}
+
+	return newLoader().processFiles(filenames)
+}
+
+// LoadMatching loads the settings from each of the files, like LoadFiles, but only applies the
+// keys starting with one of the prefixes, e.g. "MYAPP_".  The rest of each file is still parsed,
+// and any errors reported, but otherwise ignored.  If no files are given, loads the files loaded
+// by Load, which is useful when the $HOME/.env file is shared by several projects.
+func LoadMatching(prefixes []string, filenames ...string) error {
+	l := newLoader()
+	l.filter = func(key string) bool {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(filenames) == 0 {
+		return load(l)
+	}
+
+	return l.processFiles(filenames)
+}
+
+// LoadWithPrefix loads the settings from each of the files, like LoadFiles, but adds the prefix to
+// every key, so "PORT" in the file sets SVC_A_PORT given the prefix "SVC_A_".  Keys that already
+// start with the prefix are left alone.  This lets several configurations that use the same keys
+// coexist in one process.  Within a file, variables still reference the keys without the prefix.
+// If no files are given, loads the files loaded by Load.
+func LoadWithPrefix(prefix string, filenames ...string) error {
+	l := newLoader()
+	l.prefix = prefix
+
+	if len(filenames) == 0 {
+		return load(l)
+	}
+
+	return l.processFiles(filenames)
+}
+
+// LoadInto loads the settings from each of the files, like LoadFiles, but passes each one to the
+// set function rather than modifying the environment.  For example, collect the settings into a map
+// to build the environment for a child process.  If no files are given, loads the files loaded by
+// Load.  An "unset" directive in a file isn't passed to set, but does hide the variable from the
+// lines that follow.  Any error returned by set is wrapped with the file and line of the setting.
+func LoadInto(set func(key, value string) error, filenames ...string) error {
+	l := newLoader()
+	l.set = set
+
+	if len(filenames) == 0 {
+		return load(l)
+	}
+
+	return l.processFiles(filenames)
+}
+
+// LoadEnv loads the environment-specific settings, following the Rails and Node convention.  Given
+// an environment name such as "staging", loads the following files from the startup directory, in
+// order, so later files override earlier ones:
+//
+// * .env
+// * .env.staging
+// * .env.staging.local
+//
+// The .local files are intended for local overrides and shouldn't be committed to source control.
+// If envName is blank, the name is taken from the environment variable configured by the EnvVar
+// option, APP_ENV by default.  Files that don't exist are skipped.  Returns the files that were
+// actually loaded.
+func LoadEnv(envName string) ([]string, error) {
+	if envName == "" {
+		envName = os.Getenv(currentOptions().envVar())
+	}
+
+	filenames := []string{".env"}
+	if envName != "" {
+		filenames = append(filenames, ".env."+envName, ".env."+envName+".local")
+	}
+
+	var loaded []string
+	var errs []error
+
+	l := newLoader()
+	for _, filename := range filenames {
+		if !exists(filename) {
+			continue
+		}
+
+		if err := l.process(filename); err != nil {
+			errs = append(errs, fmt.Errorf("unable to load %s: %w", filename, err))
+			continue
+		}
+
+		loaded = append(loaded, filename)
+	}
+
+	if err := l.finish(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return loaded, errors.Join(errs...)
+}
+
+// LoadFS loads the environment settings from each of the files in fsys, in order, such as files
+// embedded in the application with go:embed.  Files are parsed just like LoadFiles, with any
+// included files also read from fsys.  Load files from the OS afterwards to override the settings.
+func LoadFS(fsys fs.FS, filenames ...string) error {
+	var errs []error
+
+	l := newLoader()
+	for _, filename := range filenames {
+		if err := l.processFS(fsys, filename); err != nil {
+			errs = append(errs, fmt.Errorf("unable to load %s: %w", filename, err))
+		}
+	}
+
+	if err := l.finish(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Process a file in fsys into environment variables.
+func (l *loader) processFS(fsys fs.FS, filename string) error {
+	data, err := fs.ReadFile(fsys, filename)
+	if err != nil {
+		return err
+	}
+
+	assignments, err := parseWith(fsys, l.lookup, bytes.NewReader(data), filename)
+	if err != nil {
+		return err
+	}
+
+	return l.apply(assignments)
+}
+
+// LoadReader loads the environment settings from r, such as settings decrypted from a secrets
+// store, without writing them to a file.  Errors reference the line number in the reader.
+func LoadReader(r io.Reader) error {
+	return LoadNamedReader(readerName, r)
+}
+
+// LoadNamedReader loads the environment settings from r, like LoadReader, but identifies the
+// source as name in any errors.
+func LoadNamedReader(name string, r io.Reader) error {
+	l := newLoader()
+
+	assignments, err := parseWith(nil, l.lookup, r, name)
+	if err != nil {
+		return err
+	}
+
+	if err := l.apply(assignments); err != nil {
+		return err
+	}
+
+	return l.finish()
+}
+
+// LoadString loads the environment settings from a string in the .env file format.
+func LoadString(s string) error {
+	return LoadReader(strings.NewReader(s))
+}
+
+// Process each of the files into environment variables, in order.  Returns an error naming each
+// file that failed.
+func (l *loader) processFiles(filenames []string) error {
+	var errs []error
+
+	for _, filename := range filenames {
+		if err := l.process(filename); err != nil {
+			errs = append(errs, fmt.Errorf("unable to load %s: %w", filename, err))
+		}
+	}
+
+	if err := l.finish(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// Process a file into environment variables.  The filename "-" reads from stdin.
+func (l *loader) process(filename string) error {
+	if filename == stdinName {
+		return l.processStdin()
+	}
+
+	l.files = append(l.files, loadedFile{name: filename})
+
+	assignments, err := parseFile(filename, l.lookup)
+	if err != nil {
+		return err
+	}
+
+	return l.apply(assignments)
+}
+
+// Process the settings piped to stdin, e.g. "sops -d env.enc | ./app".  Stdin is only read the
+// first time, and never if it's a terminal, as that would hang waiting for input.
+func (l *loader) processStdin() error {
+	if l.stdin {
+		return nil
+	}
+	l.stdin = true
+
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		return errors.New("stdin is a terminal; pipe the settings to the application")
+	}
+
+	assignments, err := parseWith(nil, l.lookup, os.Stdin, "stdin")
+	if err != nil {
+		return err
+	}
+
+	return l.apply(assignments)
+}
+
+// Process a flat JSON file into environment variables.
+func (l *loader) processJSON(filename string) error {
+	l.files = append(l.files, loadedFile{name: filename, json: true})
+
+	assignments, err := parseJSON(filename)
+	if err != nil {
+		return err
+	}
+
+	return l.apply(assignments)
+}
+
+// Complete the load, resolving any _FILE variables if the FileSecrets option is set, then
+// remembering the files loaded for Reload.
+func (l *loader) finish() error {
+	if currentOptions().FileSecrets {
+		if err := l.resolveFileSecrets(); err != nil {
+			return err
+		}
+	}
+
+	if !l.dryRun && l.set == nil && len(l.files) > 0 {
+		rememberLoad(l)
+	}
+
+	return nil
+}
+
+// Look up the current value of a variable, including any planned for a dry run.
+func (l *loader) lookup(key string) (string, bool) {
+	key = normalizeKey(key)
+
+	if val, ok := l.vars[key]; ok {
+		return val, true
+	}
+
+	if l.removed[key] {
+		return "", false
+	}
+
+	if l.env != nil {
+		return l.env(key)
+	}
+
+	return osLookupEnv(key)
+}
+
+// Remember the assignment, so later lookups see it even when the environment isn't being changed.
+func (l *loader) remember(a assignment) {
+	if l.vars == nil {
+		l.vars = make(map[string]string)
+		l.removed = make(map[string]bool)
+		l.origins = make(map[string]assignment)
+	}
+
+	if a.Unset {
+		delete(l.vars, a.Key)
+		l.removed[a.Key] = true
+	} else {
+		l.vars[a.Key] = a.Value
+		delete(l.removed, a.Key)
+	}
+
+	l.origins[a.Key] = a
+}
+
+// Apply the parsed assignments to the environment, in order.
+func (l *loader) apply(assignments []assignment) error {
+	for _, a := range assignments {
+		if l.prefix != "" && !strings.HasPrefix(a.Key, l.prefix) {
+			a.Key = l.prefix + a.Key
+		}
+		a.Key = normalizeKey(a.Key)
+
+		if !l.override && l.existing[a.Key] {
+			if l.report != nil {
+				l.report.Skipped++
+			}
+
+			continue
+		}
+
+		if l.filter != nil && !l.filter(a.Key) {
+			continue
+		}
+
+		if l.report != nil {
+			l.report.Set++
+		}
+
+		if l.dryRun {
+			l.plan(a)
+			continue
+		}
+
+		if l.set != nil {
+			if !a.Unset {
+				if err := l.set(a.Key, a.Value); err != nil {
+					return fmt.Errorf("failed to assign %s value %s (%s:%d): %w", a.Key, a.Value, a.File, a.Line, err)
+				}
+			}
+
+			l.remember(a)
+			continue
+		}
+
+		l.record(a.Key)
+
+		if a.Unset {
+			if err := os.Unsetenv(a.Key); err != nil {
+				return fmt.Errorf("failed to unset %s (%s:%d)", a.Key, a.File, a.Line)
+			}
+
+			untrack(a.Key)
+			l.remember(a)
+			continue
+		}
+
+		_, overwrote := os.LookupEnv(a.Key)
+
+		if err := os.Setenv(a.Key, a.Value); err != nil {
+			return fmt.Errorf("failed to assign %s value %s (%s:%d)", a.Key, a.Value, a.File, a.Line)
+		}
+
+		track(LoadedVar{Key: a.Key, Value: a.Value, File: a.File, Line: a.Line, Overwrote: overwrote})
+		l.remember(a)
+	}
+
+	return nil
+}
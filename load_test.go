@@ -0,0 +1,129 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadReaderLookup(t *testing.T) {
+	setOptions(t, Options{CaseInsensitiveKeys: true})
+	unsetenv(t, "READER_URL")
+	t.Setenv("reader_host", "example.com")
+
+	if err := LoadReader(strings.NewReader("READER_URL=http://${READER_HOST}/\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("READER_URL"); val != "http://example.com/" {
+		t.Errorf("expected ${READER_HOST} to be looked up like the file loaders do; got %q", val)
+	}
+}
+
+func TestLoadReaderSource(t *testing.T) {
+	local := writeFile(t, "local.env", "READER_SECRET=from disk\n")
+
+	err := LoadNamedReader("secrets", strings.NewReader("source "+local+"\n"))
+	if err == nil || !strings.Contains(err.Error(), "only files on disk may include other files") {
+		t.Errorf("expected the reader's source to be rejected; got %v", err)
+	}
+}
+
+func TestLoadFS(t *testing.T) {
+	unsetenv(t, "FS_PORT", "FS_HOST", "FS_NAME")
+
+	fsys := fstest.MapFS{
+		"config/common.env": {Data: []byte("FS_PORT=80\nFS_HOST=localhost\n")},
+		"config/app.env":    {Data: []byte("source common.env\nFS_PORT=8080\nFS_NAME=${FS_HOST}-app\n")},
+	}
+
+	if err := LoadFS(fsys, "config/app.env"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"FS_PORT": "8080", "FS_HOST": "localhost", "FS_NAME": "localhost-app"}
+	for key, value := range expected {
+		if val := os.Getenv(key); val != value {
+			t.Errorf("expected %s to be %q; got %q", key, value, val)
+		}
+	}
+}
+
+func TestLoadFSMissing(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.env": {Data: []byte("source missing.env\n")},
+	}
+
+	if err := LoadFS(fsys, "app.env"); err == nil || !strings.Contains(err.Error(), "missing.env") {
+		t.Errorf("expected the missing include to be reported; got %v", err)
+	}
+
+	if err := LoadFS(fsys, "other.env"); err == nil || !strings.Contains(err.Error(), "unable to load other.env") {
+		t.Errorf("expected the missing file to be reported; got %v", err)
+	}
+}
+
+func TestNoOverride(t *testing.T) {
+	setOptions(t, Options{NoOverride: true})
+	t.Setenv("NO_OVERRIDE_PORT", "9000")
+	unsetenv(t, "NO_OVERRIDE_HOST")
+
+	dir := t.TempDir()
+	base := filepath.Join(dir, "base.env")
+	local := filepath.Join(dir, "local.env")
+
+	if err := os.WriteFile(base, []byte("NO_OVERRIDE_PORT=80\nNO_OVERRIDE_HOST=base\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(local, []byte("NO_OVERRIDE_PORT=8080\nNO_OVERRIDE_HOST=local\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadFiles(base, local); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("NO_OVERRIDE_PORT"); val != "9000" {
+		t.Errorf("expected the pre-set NO_OVERRIDE_PORT to be kept; got %q", val)
+	}
+
+	if val := os.Getenv("NO_OVERRIDE_HOST"); val != "local" {
+		t.Errorf("expected the later file to override NO_OVERRIDE_HOST; got %q", val)
+	}
+}
+
+func TestLoadNoOverride(t *testing.T) {
+	setOptions(t, Options{SkipUserFile: true})
+	t.Setenv("NO_OVERRIDE_LEVEL", "warn")
+	unsetenv(t, "NO_OVERRIDE_NAME")
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("NO_OVERRIDE_LEVEL=debug\nNO_OVERRIDE_NAME=app\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	chdir(t, dir)
+
+	if err := LoadNoOverride(); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("NO_OVERRIDE_LEVEL"); val != "warn" {
+		t.Errorf("expected LoadNoOverride to keep the pre-set NO_OVERRIDE_LEVEL; got %q", val)
+	}
+
+	if val := os.Getenv("NO_OVERRIDE_NAME"); val != "app" {
+		t.Errorf("expected LoadNoOverride to set NO_OVERRIDE_NAME; got %q", val)
+	}
+
+	setOptions(t, Options{SkipUserFile: true, NoOverride: true})
+	if err := Overload(); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("NO_OVERRIDE_LEVEL"); val != "debug" {
+		t.Errorf("expected Overload to replace NO_OVERRIDE_LEVEL, despite NoOverride; got %q", val)
+	}
+}
@@ -0,0 +1,70 @@
+package dotenv
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// GetLogLevel returns the environment variable as a slog.Level, such as LOG_LEVEL=debug.  Accepts
+// the slog level names, ignoring case, with an optional offset such as "info+2", "warning" for
+// "warn", and numeric levels such as "-4".  If the environment variable doesn't exist or isn't a
+// level, returns the default value if present, otherwise slog.LevelInfo.  The default may be a
+// slog.Level or the name of one.
+func GetLogLevel(key string) slog.Level {
+	if level, err := GetLogLevelE(key); err == nil {
+		return level
+	}
+
+	return defaultLogLevel(key)
+}
+
+// GetLogLevelE returns the environment variable as a slog.Level, like GetLogLevel, but returns a
+// *ParseValueError if the environment variable is set but isn't a level, rather than falling back
+// to the default.
+func GetLogLevelE(key string) (slog.Level, error) {
+	if val, set := lookupEnv(key); set {
+		level, err := parseLogLevel(val)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: typeNames[LogLevelType], Err: err}
+		}
+
+		return level, nil
+	}
+
+	return defaultLogLevel(key), nil
+}
+
+// Parse the log level name or number.
+func parseLogLevel(val string) (slog.Level, error) {
+	val = strings.TrimSpace(val)
+
+	if n, err := strconv.Atoi(val); err == nil {
+		return slog.Level(n), nil
+	}
+
+	if len(val) >= len("warning") && strings.EqualFold(val[:len("warning")], "warning") {
+		val = "warn" + val[len("warning"):]
+	}
+
+	var level slog.Level
+	err := level.UnmarshalText([]byte(val))
+
+	return level, err
+}
+
+// Returns the registered default log level, parsing it if it's a string, or slog.LevelInfo.
+func defaultLogLevel(key string) slog.Level {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case slog.Level:
+			return defaultValue
+		case string:
+			if level, err := parseLogLevel(defaultValue); err == nil {
+				return level
+			}
+		}
+	}
+
+	return slog.LevelInfo
+}
@@ -0,0 +1,74 @@
+package dotenv
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected slog.Level
+		invalid  bool
+	}{
+		{val: "debug", expected: slog.LevelDebug},
+		{val: "DEBUG", expected: slog.LevelDebug},
+		{val: "Info", expected: slog.LevelInfo},
+		{val: "warn", expected: slog.LevelWarn},
+		{val: "warning", expected: slog.LevelWarn},
+		{val: "WARNING+1", expected: slog.LevelWarn + 1},
+		{val: "error", expected: slog.LevelError},
+		{val: "info+2", expected: slog.LevelInfo + 2},
+		{val: " error ", expected: slog.LevelError},
+		{val: "-4", expected: slog.LevelDebug},
+		{val: "12", expected: slog.Level(12)},
+		{val: "verbose", invalid: true},
+		{val: "", invalid: true},
+	}
+
+	for _, test := range tests {
+		level, err := parseLogLevel(test.val)
+		if test.invalid {
+			if err == nil {
+				t.Errorf("%q: expected an error; got %s", test.val, level)
+			}
+
+			continue
+		}
+
+		if err != nil || level != test.expected {
+			t.Errorf("%q: expected %s; got %s, %v", test.val, test.expected, level, err)
+		}
+	}
+}
+
+func TestGetLogLevelDefault(t *testing.T) {
+	unsetenv(t, "LOGLEVEL_UNSET", "LOGLEVEL_LEVEL", "LOGLEVEL_NAME")
+	t.Setenv("LOGLEVEL_INVALID", "verbose")
+
+	Register("LOGLEVEL_LEVEL", slog.LevelWarn, "A level")
+	Register("LOGLEVEL_NAME", "debug", "A level name")
+	Register("LOGLEVEL_INVALID", slog.LevelError, "An invalid level")
+
+	tests := []struct {
+		key      string
+		expected slog.Level
+	}{
+		{key: "LOGLEVEL_UNSET", expected: slog.LevelInfo},
+		{key: "LOGLEVEL_LEVEL", expected: slog.LevelWarn},
+		{key: "LOGLEVEL_NAME", expected: slog.LevelDebug},
+		{key: "LOGLEVEL_INVALID", expected: slog.LevelError},
+	}
+
+	for _, test := range tests {
+		if level := GetLogLevel(test.key); level != test.expected {
+			t.Errorf("%s: expected %s; got %s", test.key, test.expected, level)
+		}
+	}
+
+	var perr *ParseValueError
+	if _, err := GetLogLevelE("LOGLEVEL_INVALID"); !errors.As(err, &perr) || perr.Raw != "verbose" {
+		t.Errorf("expected a ParseValueError for the invalid level; got %v", err)
+	}
+}
@@ -0,0 +1,265 @@
+package dotenv
+
+import (
+	"encoding/hex"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The Lookup functions mirror os.LookupEnv: the bool is true only if the environment variable is
+// set and its value is valid for the type.  Registered defaults are ignored, so callers can tell
+// "not set" from "set to the zero value".
+
+// LookupString returns the environment variable and true if it's set, even if it's set to the
+// blank string.  Otherwise returns a blank string and false.
+func LookupString(key string) (string, bool) {
+	return lookupEnv(key)
+}
+
+// LookupStringSlice returns the environment variable as a comma-separated list of strings, and true
+// if it's set.
+func LookupStringSlice(key string) ([]string, bool) {
+	if val, set := lookupEnv(key); set {
+		return splitList(val, ","), true
+	}
+
+	return nil, false
+}
+
+// LookupInt returns the environment variable as an integer, and true if it's set to an integer.
+func LookupInt(key string) (int, bool) {
+	return lookup(key, strconv.Atoi)
+}
+
+// LookupInt64 returns the environment variable as an int64, and true if it's set to an integer.
+func LookupInt64(key string) (int64, bool) {
+	return lookup(key, func(val string) (int64, error) { return strconv.ParseInt(val, 10, 64) })
+}
+
+// LookupUint returns the environment variable as an unsigned integer, and true if it's set to an
+// unsigned integer.
+func LookupUint(key string) (uint, bool) {
+	return lookup(key, func(val string) (uint, error) {
+		uval, err := strconv.ParseUint(val, 10, strconv.IntSize)
+		return uint(uval), err
+	})
+}
+
+// LookupUint64 returns the environment variable as a uint64, and true if it's set to an unsigned
+// integer.
+func LookupUint64(key string) (uint64, bool) {
+	return lookup(key, func(val string) (uint64, error) { return strconv.ParseUint(val, 10, 64) })
+}
+
+// LookupFloat64 returns the environment variable as a float64, and true if it's set to a number.
+func LookupFloat64(key string) (float64, bool) {
+	return lookup(key, func(val string) (float64, error) { return strconv.ParseFloat(val, 64) })
+}
+
+// LookupBool returns the environment variable as a boolean, and true if it's set to "true" or
+// "false", in any case.
+func LookupBool(key string) (bool, bool) {
+	if val, set := lookupEnv(key); set {
+		switch {
+		case strings.EqualFold(val, "true"):
+			return true, true
+		case strings.EqualFold(val, "false"):
+			return false, true
+		}
+	}
+
+	return false, false
+}
+
+// LookupDuration returns the environment variable as a time.Duration, and true if it's set to a
+// duration.
+func LookupDuration(key string) (time.Duration, bool) {
+	unit := currentOptions().DurationUnit
+	return lookup(key, func(val string) (time.Duration, error) { return parseDuration(val, unit) })
+}
+
+// LookupBytes returns the environment variable as a number of bytes, and true if it's set to a
+// size, such as 25MB.  See GetBytes.
+func LookupBytes(key string) (int64, bool) {
+	return lookup(key, parseBytes)
+}
+
+// LookupTime returns the environment variable as a time.Time, and true if it's set to a time in
+// any of the formats accepted by GetTime.
+func LookupTime(key string) (time.Time, bool) {
+	return lookup(key, parseTime)
+}
+
+// LookupTimeLayout returns the environment variable as a time.Time, and true if it's set to a time
+// matching the layout.
+func LookupTimeLayout(key, layout string) (time.Time, bool) {
+	return lookup(key, func(val string) (time.Time, error) { return time.Parse(layout, val) })
+}
+
+// LookupIntSlice returns the environment variable as a comma-separated list of integers, and true
+// if it's set and every value is an integer.
+func LookupIntSlice(key string) ([]int, bool) {
+	return lookup(key, func(val string) ([]int, error) { return parseList(val, strconv.Atoi) })
+}
+
+// LookupInt64Slice returns the environment variable as a comma-separated list of int64 values, and
+// true if it's set and every value is an integer.
+func LookupInt64Slice(key string) ([]int64, bool) {
+	return lookup(key, func(val string) ([]int64, error) {
+		return parseList(val, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+	})
+}
+
+// LookupFloat64Slice returns the environment variable as a comma-separated list of float64 values,
+// and true if it's set and every value is a number.
+func LookupFloat64Slice(key string) ([]float64, bool) {
+	return lookup(key, func(val string) ([]float64, error) {
+		return parseList(val, func(s string) (float64, error) { return strconv.ParseFloat(s, 64) })
+	})
+}
+
+// LookupDurationSlice returns the environment variable as a comma-separated list of durations, and
+// true if it's set and every value is a duration.
+func LookupDurationSlice(key string) ([]time.Duration, bool) {
+	return lookup(key, parseDurations)
+}
+
+// LookupStringMap returns the environment variable as a map of keys to values, and true if it's set
+// and every entry has a value.  See GetStringMap.
+func LookupStringMap(key string) (map[string]string, bool) {
+	return lookup(key, func(val string) (map[string]string, error) { return parseMap(val, ";", "=") })
+}
+
+// LookupURL returns the environment variable as a URL, and true if it's set to an absolute URL.
+func LookupURL(key string) (*url.URL, bool) {
+	return lookup(key, parseURL)
+}
+
+// LookupIP returns the environment variable as an IP address, and true if it's set to an IP
+// address.
+func LookupIP(key string) (net.IP, bool) {
+	if val, set := lookupEnv(key); set {
+		if ip := net.ParseIP(val); ip != nil {
+			return ip, true
+		}
+	}
+
+	return nil, false
+}
+
+// LookupCIDR returns the environment variable as an IP network, and true if it's set to a network
+// in CIDR notation.
+func LookupCIDR(key string) (*net.IPNet, bool) {
+	return lookup(key, func(val string) (*net.IPNet, error) {
+		_, network, err := net.ParseCIDR(val)
+		return network, err
+	})
+}
+
+// LookupCIDRSlice returns the environment variable as a comma-separated list of IP networks, and
+// true if it's set and every value is a network.
+func LookupCIDRSlice(key string) ([]*net.IPNet, bool) {
+	return lookup(key, parseCIDRs)
+}
+
+// LookupBase64 returns the environment variable decoded from base64, and true if it's set and
+// decodes.
+func LookupBase64(key string) ([]byte, bool) {
+	return lookup(key, decodeBase64)
+}
+
+// LookupHex returns the environment variable decoded from hexadecimal, and true if it's set and
+// decodes.
+func LookupHex(key string) ([]byte, bool) {
+	return lookup(key, hex.DecodeString)
+}
+
+// LookupRegexp returns the environment variable compiled as a regular expression, and true if it's
+// set and compiles.
+func LookupRegexp(key string) (*regexp.Regexp, bool) {
+	return lookup(key, regexp.Compile)
+}
+
+// Parse the environment variable, if it's set, returning true only if it parses.
+func lookup[T any](key string, parse func(string) (T, error)) (T, bool) {
+	var zero T
+
+	val, set := lookupEnv(key)
+	if !set {
+		return zero, false
+	}
+
+	v, err := parse(val)
+	if err != nil {
+		return zero, false
+	}
+
+	return v, true
+}
+
+// LookupEnum returns the environment variable as written in the allowed values, and true if it
+// matches one of them, ignoring case.  If no values are given, uses those passed to RegisterEnum.
+func LookupEnum(key string, allowed ...string) (string, bool) {
+	if _, set := lookupEnv(key); !set {
+		return "", false
+	}
+
+	if val, err := GetEnumE(key, allowed...); err == nil {
+		return val, true
+	}
+
+	return "", false
+}
+
+// LookupLogLevel returns the environment variable as a slog.Level, and true if it's set to a level.
+func LookupLogLevel(key string) (slog.Level, bool) {
+	return lookup(key, parseLogLevel)
+}
+
+// LookupTimeZone returns the environment variable as a time zone, and true if it's set to a known
+// zone or offset.
+func LookupTimeZone(key string) (*time.Location, bool) {
+	return lookup(key, parseTimeZone)
+}
+
+// LookupPort returns the environment variable as a port number, and true if it's set to a valid
+// port.
+func LookupPort(key string) (int, bool) {
+	return lookup(key, parsePort)
+}
+
+// LookupCSV returns the environment variable parsed as a single CSV record, like GetCSV, and true
+// if it's set to valid CSV.
+func LookupCSV(key string) ([]string, bool) {
+	return lookup(key, parseCSV)
+}
+
+// LookupPercent returns the environment variable as a fraction between 0 and 1, like GetPercent,
+// and true if it's set to a valid percentage.
+func LookupPercent(key string) (float64, bool) {
+	return lookup(key, parsePercent)
+}
+
+// LookupBigInt returns the environment variable as an arbitrarily large integer, and true if it's
+// set to an integer.
+func LookupBigInt(key string) (*big.Int, bool) {
+	return lookup(key, parseBigInt)
+}
+
+// LookupRat returns the environment variable as an exact fraction, and true if it's set to a
+// number.
+func LookupRat(key string) (*big.Rat, bool) {
+	return lookup(key, parseRat)
+}
+
+// LookupSemVer returns the environment variable as a semantic version, and true if it's set to a
+// valid version.
+func LookupSemVer(key string) (Version, bool) {
+	return lookup(key, ParseVersion)
+}
@@ -0,0 +1,231 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		name     string
+		lookup   func(key string) (interface{}, bool)
+		valid    string
+		invalid  string
+		expected interface{}
+	}{
+		{
+			name:     "LookupStringSlice",
+			lookup:   func(key string) (interface{}, bool) { return LookupStringSlice(key) },
+			valid:    "a, b",
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "LookupInt",
+			lookup:   func(key string) (interface{}, bool) { return LookupInt(key) },
+			valid:    "-42",
+			invalid:  "forty",
+			expected: -42,
+		},
+		{
+			name:     "LookupInt64",
+			lookup:   func(key string) (interface{}, bool) { return LookupInt64(key) },
+			valid:    "9000000000",
+			invalid:  "1.5",
+			expected: int64(9000000000),
+		},
+		{
+			name:     "LookupUint",
+			lookup:   func(key string) (interface{}, bool) { return LookupUint(key) },
+			valid:    "42",
+			invalid:  "-42",
+			expected: uint(42),
+		},
+		{
+			name:     "LookupUint64",
+			lookup:   func(key string) (interface{}, bool) { return LookupUint64(key) },
+			valid:    "42",
+			invalid:  "-42",
+			expected: uint64(42),
+		},
+		{
+			name:     "LookupFloat64",
+			lookup:   func(key string) (interface{}, bool) { return LookupFloat64(key) },
+			valid:    "2.5",
+			invalid:  "two",
+			expected: 2.5,
+		},
+		{
+			name:     "LookupBool",
+			lookup:   func(key string) (interface{}, bool) { return LookupBool(key) },
+			valid:    "FALSE",
+			invalid:  "maybe",
+			expected: false,
+		},
+		{
+			name:     "LookupDuration",
+			lookup:   func(key string) (interface{}, bool) { return LookupDuration(key) },
+			valid:    "1m30s",
+			invalid:  "soon",
+			expected: 90 * time.Second,
+		},
+		{
+			name:     "LookupBytes",
+			lookup:   func(key string) (interface{}, bool) { return LookupBytes(key) },
+			valid:    "2KB",
+			invalid:  "lots",
+			expected: int64(2000),
+		},
+		{
+			name:     "LookupTime",
+			lookup:   func(key string) (interface{}, bool) { return LookupTime(key) },
+			valid:    "2024-03-01T12:00:00Z",
+			invalid:  "yesterday",
+			expected: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "LookupTimeLayout",
+			lookup:   func(key string) (interface{}, bool) { return LookupTimeLayout(key, "2006/01/02") },
+			valid:    "2024/03/01",
+			invalid:  "2024-03-01",
+			expected: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "LookupIntSlice",
+			lookup:   func(key string) (interface{}, bool) { return LookupIntSlice(key) },
+			valid:    "1,2,3",
+			invalid:  "1,two,3",
+			expected: []int{1, 2, 3},
+		},
+		{
+			name:     "LookupInt64Slice",
+			lookup:   func(key string) (interface{}, bool) { return LookupInt64Slice(key) },
+			valid:    "1,9000000000",
+			invalid:  "1,two",
+			expected: []int64{1, 9000000000},
+		},
+		{
+			name:     "LookupFloat64Slice",
+			lookup:   func(key string) (interface{}, bool) { return LookupFloat64Slice(key) },
+			valid:    "0.5,1.5",
+			invalid:  "0.5,half",
+			expected: []float64{0.5, 1.5},
+		},
+		{
+			name:     "LookupDurationSlice",
+			lookup:   func(key string) (interface{}, bool) { return LookupDurationSlice(key) },
+			valid:    "1s,2m",
+			invalid:  "1s,later",
+			expected: []time.Duration{time.Second, 2 * time.Minute},
+		},
+		{
+			name:     "LookupStringMap",
+			lookup:   func(key string) (interface{}, bool) { return LookupStringMap(key) },
+			valid:    "a=1;b=2",
+			invalid:  "a=1;b",
+			expected: map[string]string{"a": "1", "b": "2"},
+		},
+		{
+			name:     "LookupHex",
+			lookup:   func(key string) (interface{}, bool) { return LookupHex(key) },
+			valid:    "cafe",
+			invalid:  "coffee",
+			expected: []byte{0xca, 0xfe},
+		},
+		{
+			name:     "LookupBase64",
+			lookup:   func(key string) (interface{}, bool) { return LookupBase64(key) },
+			valid:    "aGk=",
+			invalid:  "!!!",
+			expected: []byte("hi"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Register("LOOKUP_VALUE", test.valid, "Ignored by the Lookup functions")
+
+			unsetenv(t, "LOOKUP_VALUE")
+			if val, ok := test.lookup("LOOKUP_VALUE"); ok {
+				t.Errorf("expected an unset variable to be reported as missing, ignoring the default; got %v", val)
+			}
+
+			t.Setenv("LOOKUP_VALUE", test.valid)
+			if val, ok := test.lookup("LOOKUP_VALUE"); !ok || !reflect.DeepEqual(val, test.expected) {
+				t.Errorf("%q: expected %v, true; got %v, %v", test.valid, test.expected, val, ok)
+			}
+
+			if test.invalid == "" {
+				return
+			}
+
+			t.Setenv("LOOKUP_VALUE", test.invalid)
+			if val, ok := test.lookup("LOOKUP_VALUE"); ok {
+				t.Errorf("%q: expected an invalid value to be reported as missing; got %v", test.invalid, val)
+			}
+		})
+	}
+}
+
+func TestLookupString(t *testing.T) {
+	unsetenv(t, "LOOKUP_STRING")
+	Register("LOOKUP_STRING", "default", "Ignored by LookupString")
+
+	if _, ok := LookupString("LOOKUP_STRING"); ok {
+		t.Error("expected an unset variable to be reported as missing")
+	}
+
+	t.Setenv("LOOKUP_STRING", "")
+	if val, ok := LookupString("LOOKUP_STRING"); !ok || val != "" {
+		t.Errorf("expected a blank variable to be reported as set; got %q, %v", val, ok)
+	}
+}
+
+func TestLookupURLAndIP(t *testing.T) {
+	t.Setenv("LOOKUP_URL", "https://example.com/path")
+	t.Setenv("LOOKUP_BAD_URL", "example.com")
+	t.Setenv("LOOKUP_IP", "10.0.0.1")
+	t.Setenv("LOOKUP_BAD_IP", "10.0.0")
+	t.Setenv("LOOKUP_CIDR", "10.0.0.0/8")
+	t.Setenv("LOOKUP_CIDRS", "10.0.0.0/8,192.168.0.0/16")
+	t.Setenv("LOOKUP_REGEXP", "^a+$")
+	t.Setenv("LOOKUP_BAD_REGEXP", "a(")
+	unsetenv(t, "LOOKUP_MISSING")
+
+	if u, ok := LookupURL("LOOKUP_URL"); !ok || u.Host != "example.com" {
+		t.Errorf("expected the URL to be parsed; got %v, %v", u, ok)
+	}
+
+	if ip, ok := LookupIP("LOOKUP_IP"); !ok || ip.String() != "10.0.0.1" {
+		t.Errorf("expected the IP to be parsed; got %v, %v", ip, ok)
+	}
+
+	if network, ok := LookupCIDR("LOOKUP_CIDR"); !ok || network.String() != "10.0.0.0/8" {
+		t.Errorf("expected the network to be parsed; got %v, %v", network, ok)
+	}
+
+	if networks, ok := LookupCIDRSlice("LOOKUP_CIDRS"); !ok || len(networks) != 2 {
+		t.Errorf("expected both networks to be parsed; got %v, %v", networks, ok)
+	}
+
+	if re, ok := LookupRegexp("LOOKUP_REGEXP"); !ok || !re.MatchString("aaa") {
+		t.Errorf("expected the regular expression to be compiled; got %v, %v", re, ok)
+	}
+
+	invalid := map[string]func(string) bool{
+		"LOOKUP_BAD_URL":    func(key string) bool { _, ok := LookupURL(key); return ok },
+		"LOOKUP_BAD_IP":     func(key string) bool { _, ok := LookupIP(key); return ok },
+		"LOOKUP_IP":         func(key string) bool { _, ok := LookupCIDR(key); return ok },
+		"LOOKUP_BAD_REGEXP": func(key string) bool { _, ok := LookupRegexp(key); return ok },
+	}
+
+	for key, lookup := range invalid {
+		if lookup(key) {
+			t.Errorf("expected %s to be reported as missing", key)
+		}
+
+		if lookup("LOOKUP_MISSING") {
+			t.Errorf("expected LOOKUP_MISSING to be reported as missing")
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package dotenv
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// The Must functions get settings the application can't run without, such as DATABASE_URL.  If the
+// environment variable isn't set and has no default, or is set to an invalid value, they call the
+// FatalHandler option, or panic if it isn't set, with an error naming the key, the value, and the
+// expected type.  If the FatalHandler returns, so does the Must function, with the zero value.
+
+// MustGetString returns the environment variable, like GetString, but fails if it isn't set and
+// has no default.
+func MustGetString(key string) string {
+	return mustGet(key, StringType, LookupString, GetString)
+}
+
+// MustGetStringSlice returns the environment variable as a string slice, like GetStringSlice, but
+// fails if it isn't set and has no default.
+func MustGetStringSlice(key string) []string {
+	return mustGet(key, StringSliceType, LookupStringSlice, GetStringSlice)
+}
+
+// MustGetInt returns the environment variable as an integer, like GetInt, but fails if it isn't set
+// to an integer and has no default.
+func MustGetInt(key string) int {
+	return mustGet(key, IntType, LookupInt, GetInt)
+}
+
+// MustGetInt64 returns the environment variable as an int64, like GetInt64, but fails if it isn't
+// set to an integer and has no default.
+func MustGetInt64(key string) int64 {
+	return mustGet(key, Int64Type, LookupInt64, GetInt64)
+}
+
+// MustGetUint returns the environment variable as an unsigned integer, like GetUint, but fails if
+// it isn't set to an unsigned integer and has no default.
+func MustGetUint(key string) uint {
+	return mustGet(key, UintType, LookupUint, GetUint)
+}
+
+// MustGetUint64 returns the environment variable as a uint64, like GetUint64, but fails if it isn't
+// set to an unsigned integer and has no default.
+func MustGetUint64(key string) uint64 {
+	return mustGet(key, Uint64Type, LookupUint64, GetUint64)
+}
+
+// MustGetFloat64 returns the environment variable as a float64, like GetFloat64, but fails if it
+// isn't set to a number and has no default.
+func MustGetFloat64(key string) float64 {
+	return mustGet(key, Float64Type, LookupFloat64, GetFloat64)
+}
+
+// MustGetBool returns the environment variable as a boolean, like GetBool, but fails if it isn't
+// set to "true" or "false" and has no default.
+func MustGetBool(key string) bool {
+	return mustGet(key, BoolType, LookupBool, GetBool)
+}
+
+// MustGetDuration returns the environment variable as a time.Duration, like GetDuration, but fails
+// if it isn't set to a duration and has no default.
+func MustGetDuration(key string) time.Duration {
+	return mustGet(key, DurationType, LookupDuration, GetDuration)
+}
+
+// MustGetTime returns the environment variable as a time.Time, like GetTime, but fails if it isn't
+// set to a time and has no default.
+func MustGetTime(key string) time.Time {
+	return mustGet(key, TimeType, LookupTime, GetTime)
+}
+
+// MustGetURL returns the environment variable as an absolute URL, like GetURL, but fails if it
+// isn't set to an absolute URL and has no default.
+func MustGetURL(key string) *url.URL {
+	return mustGet(key, URLType, LookupURL, GetURL)
+}
+
+// Get the valid value of the environment variable, or its default, or fail.
+func mustGet[T any](key string, dataType int, lookup func(string) (T, bool), get func(string) T) T {
+	var zero T
+
+	val, set := lookupEnv(key)
+	if !set {
+		if _, ok := Default(key); ok {
+			return get(key)
+		}
+
+		fatal(fmt.Errorf("%s: %w and has no default (%s); call dotenv.Help to list the settings", key, ErrNotSet, typeNames[dataType]))
+		return zero
+	}
+
+	if v, ok := lookup(key); ok {
+		return v
+	}
+
+	display := fmt.Sprintf("%q", val)
+	if descriptor, ok := Default(key); ok && descriptor.Secret {
+		display = redacted
+	}
+
+	fatal(fmt.Errorf("%s is set to %s, which is not a valid %s; call dotenv.Help to list the settings", key, display, typeNames[dataType]))
+	return zero
+}
+
+// Report a missing or invalid setting to the FatalHandler, or panic.
+func fatal(err error) {
+	if handler := currentOptions().FatalHandler; handler != nil {
+		handler(err)
+		return
+	}
+
+	panic(err)
+}
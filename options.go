@@ -0,0 +1,215 @@
+package dotenv
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Options control how the .env files are processed by Load.
+type Options struct {
+	// ErrOnDuplicateKeys causes Load to fail if a key is assigned more than once in the same .env
+	// file.  By default the last assignment wins.  Keys in the local .env file overriding those
+	// in the $HOME/.env file are never considered duplicates.
+	ErrOnDuplicateKeys bool
+
+	// AllowInvalidKeys disables the check that keys are valid POSIX environment variable names,
+	// i.e. letters, digits, and underscores, not starting with a digit.
+	AllowInvalidKeys bool
+
+	// NormalizeKeys converts keys to upper case and replaces any "-" or "." characters with
+	// underscores, so "log.level" in the file sets the LOG_LEVEL environment variable.
+	NormalizeKeys bool
+
+	// CaseInsensitiveKeys matches keys regardless of case, as Windows does, so "Port", "port", and
+	// "PORT" are the same setting.  Keys are converted to upper case when registered, looked up,
+	// and loaded, so a default registered as "Port" is returned by GetInt("PORT"), and displayed by
+	// Help as PORT.  The getters also find variables in the environment set with a different case.
+	CaseInsensitiveKeys bool
+
+	// AllowCommandSubstitution replaces $(command) in unquoted and double-quoted values with the
+	// trimmed output of running the command with the shell.  Never enable this when loading
+	// files you don't trust.
+	AllowCommandSubstitution bool
+
+	// AppendSeparator is placed between the current value and the new value by a KEY += value
+	// assignment, e.g. "," or ":".  By default the value is appended as is.
+	AppendSeparator string
+
+	// AllowBareKeys treats a line containing only a key, e.g. ENABLE_TRACING, as a flag set to
+	// "true".  Any other line without an assignment is an error.  By default these lines are
+	// ignored.
+	AllowBareKeys bool
+
+	// NoOverride leaves any environment variables that were already set before loading alone, so
+	// the .env files only provide defaults.  Settings in later files still override those in
+	// earlier ones.  See also Overload and LoadNoOverride.
+	NoOverride bool
+
+	// EnvVar names the environment variable LoadEnv uses to determine the environment name, e.g.
+	// "staging".  Defaults to APP_ENV.
+	EnvVar string
+
+	// SearchParents has Load look for the local .env file in the parent directories if it's not
+	// in the startup directory, e.g. when running "go test" in a package directory.  The search
+	// stops at the first .env file found, or at the root of the project, i.e. a directory
+	// containing a go.mod file or .git directory.  The $HOME/.env file is unaffected.
+	SearchParents bool
+
+	// AllowEmptyGlob lets LoadGlob succeed when no files match the pattern.
+	AllowEmptyGlob bool
+
+	// KeyFilter, if set, is called with each key loaded from the files, and only the keys it
+	// returns true for are applied.  The rest of the file is still parsed, and any errors
+	// reported.  See also LoadMatching.
+	KeyFilter func(key string) bool
+
+	// ReloadUnsetsRemoved has Reload unset any variables that have been removed from the files.
+	// By default they're left alone.
+	ReloadUnsetsRemoved bool
+
+	// ReadRetries is the number of times to read a .env file again if it can't be parsed or
+	// changes while being read, e.g. when another tool is regenerating it.  By default files are
+	// read only once.
+	ReadRetries int
+
+	// ReadRetryDelay is how long to wait before reading a file again, doubled for the second retry,
+	// tripled for the third, and so on.  Defaults to 50ms.
+	ReadRetryDelay time.Duration
+
+	// WatchInterval is how often Watch checks the file for changes.  Defaults to one second.
+	WatchInterval time.Duration
+
+	// WatchErrorHandler is called by Watch when a changed file can't be read or parsed.  Watch
+	// keeps watching the file regardless.
+	WatchErrorHandler func(err error)
+
+	// LocalFile is the name of the file Load reads from the startup directory.  Defaults to .env.
+	// The JSON settings are read from the same name with a .json extension, e.g. .env.json.
+	LocalFile string
+
+	// UserFile is the name of the file Load reads from the user's home directory.  Defaults to
+	// .env.
+	UserFile string
+
+	// OSFile has Load also read the local file for the operating system after the local .env
+	// file, e.g. .env.darwin or .env.linux, named for runtime.GOOS.  Its settings override the
+	// local .env file.
+	OSFile bool
+
+	// SkipUserFile stops Load from reading the file in the user's home directory, so stale
+	// personal settings can't interfere with the project's.
+	SkipUserFile bool
+
+	// FileSecrets has the Load functions resolve any variables ending in _FILE after loading, e.g.
+	// setting DB_PASSWORD to the contents of the file named by DB_PASSWORD_FILE.  See
+	// ResolveFileSecrets.
+	FileSecrets bool
+
+	// FileContentsMaxSize is the largest file GetFileContents reads, in bytes.  Defaults to 10MB.
+	FileContentsMaxSize int64
+
+	// DurationUnit has GetDuration treat a bare number without a unit, such as "30", as a number of
+	// these units, e.g. time.Second.  By default a bare number isn't a valid duration.
+	DurationUnit time.Duration
+
+	// AllowZeroPort has GetPort accept port 0, which has the operating system pick any free port.
+	AllowZeroPort bool
+
+	// AliasHandler is called the first time a getter uses a deprecated alias for an environment
+	// variable, registered with RegisterAlias, e.g. to log a warning to migrate to the new name.
+	AliasHandler func(key, alias string)
+
+	// FatalHandler is called by the Must functions, such as MustGetString, when a setting is
+	// missing or invalid, e.g. to log the error and exit.  By default they panic.
+	FatalHandler func(err error)
+
+	// URLHeaders are added to the request made by LoadURL, e.g. an Authorization header.
+	URLHeaders http.Header
+
+	// URLMaxSize is the largest response LoadURL accepts, in bytes.  Defaults to 4MB.
+	URLMaxSize int64
+}
+
+// The name of the local file loaded by Load.
+func (o Options) localFile() string {
+	if o.LocalFile == "" {
+		return ".env"
+	}
+
+	return o.LocalFile
+}
+
+// The name of the file in the user's home directory loaded by Load.
+func (o Options) userFile() string {
+	if o.UserFile == "" {
+		return ".env"
+	}
+
+	return o.UserFile
+}
+
+// The largest response accepted by LoadURL.
+func (o Options) urlMaxSize() int64 {
+	if o.URLMaxSize <= 0 {
+		return defaultURLMaxSize
+	}
+
+	return o.URLMaxSize
+}
+
+// The largest file read by GetFileContents.
+func (o Options) fileContentsMaxSize() int64 {
+	if o.FileContentsMaxSize <= 0 {
+		return defaultFileContentsMaxSize
+	}
+
+	return o.FileContentsMaxSize
+}
+
+// The environment variable naming the environment for LoadEnv.
+func (o Options) envVar() string {
+	if o.EnvVar == "" {
+		return "APP_ENV"
+	}
+
+	return o.EnvVar
+}
+
+var options Options
+var optMutex sync.RWMutex
+
+// SetOptions configures how subsequent calls to Load process .env files.  Thread-safe.
+func SetOptions(opts Options) {
+	optMutex.Lock()
+	defer optMutex.Unlock()
+
+	options = opts
+}
+
+// CaseInsensitiveKeys turns the CaseInsensitiveKeys option on or off, leaving the other options
+// alone.  Turn it on before registering any defaults, so their keys are converted too.
+func CaseInsensitiveKeys(on bool) {
+	optMutex.Lock()
+	defer optMutex.Unlock()
+
+	options.CaseInsensitiveKeys = on
+}
+
+// Convert the key to upper case if the CaseInsensitiveKeys option is set.
+func normalizeKey(key string) string {
+	if currentOptions().CaseInsensitiveKeys {
+		return strings.ToUpper(key)
+	}
+
+	return key
+}
+
+// Returns a copy of the currently configured options.
+func currentOptions() Options {
+	optMutex.RLock()
+	defer optMutex.RUnlock()
+
+	return options
+}
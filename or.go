@@ -0,0 +1,281 @@
+package dotenv
+
+import (
+	"log/slog"
+	"net"
+	"net/url"
+	"regexp"
+	"time"
+)
+
+// The Or functions return the fallback if the environment variable isn't set or its value isn't
+// valid for the type, for one-off settings that don't warrant a call to Register.  The fallback
+// takes precedence over any registered default, so the result doesn't depend on what other
+// packages have registered.
+
+// GetStringOr returns the environment variable as a string, or the fallback.
+func GetStringOr(key string, fallback string) string {
+	if val, ok := LookupString(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetStringSliceOr returns the environment variable as a comma-separated list of strings, or the
+// fallback.
+func GetStringSliceOr(key string, fallback []string) []string {
+	if val, ok := LookupStringSlice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetIntOr returns the environment variable as an integer, or the fallback.
+func GetIntOr(key string, fallback int) int {
+	if val, ok := LookupInt(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetInt64Or returns the environment variable as an int64, or the fallback.
+func GetInt64Or(key string, fallback int64) int64 {
+	if val, ok := LookupInt64(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetUintOr returns the environment variable as an unsigned integer, or the fallback.
+func GetUintOr(key string, fallback uint) uint {
+	if val, ok := LookupUint(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetUint64Or returns the environment variable as a uint64, or the fallback.
+func GetUint64Or(key string, fallback uint64) uint64 {
+	if val, ok := LookupUint64(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetFloat64Or returns the environment variable as a float64, or the fallback.
+func GetFloat64Or(key string, fallback float64) float64 {
+	if val, ok := LookupFloat64(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetBoolOr returns the environment variable as a boolean, or the fallback.
+func GetBoolOr(key string, fallback bool) bool {
+	if val, ok := LookupBool(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetDurationOr returns the environment variable as a duration, or the fallback.
+func GetDurationOr(key string, fallback time.Duration) time.Duration {
+	if val, ok := LookupDuration(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetTimeOr returns the environment variable as a time, or the fallback.
+func GetTimeOr(key string, fallback time.Time) time.Time {
+	if val, ok := LookupTime(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetURLOr returns the environment variable as an absolute URL, or the fallback.
+func GetURLOr(key string, fallback *url.URL) *url.URL {
+	if val, ok := LookupURL(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetIPOr returns the environment variable as an IP address, or the fallback.
+func GetIPOr(key string, fallback net.IP) net.IP {
+	if val, ok := LookupIP(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetCIDROr returns the environment variable as a CIDR network, or the fallback.
+func GetCIDROr(key string, fallback *net.IPNet) *net.IPNet {
+	if val, ok := LookupCIDR(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetCIDRSliceOr returns the environment variable as a comma-separated list of CIDR networks, or
+// the fallback.
+func GetCIDRSliceOr(key string, fallback []*net.IPNet) []*net.IPNet {
+	if val, ok := LookupCIDRSlice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetBytesOr returns the environment variable as a size in bytes, such as "10MB", or the fallback.
+func GetBytesOr(key string, fallback int64) int64 {
+	if val, ok := LookupBytes(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetIntSliceOr returns the environment variable as a comma-separated list of integers, or the
+// fallback.
+func GetIntSliceOr(key string, fallback []int) []int {
+	if val, ok := LookupIntSlice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetInt64SliceOr returns the environment variable as a comma-separated list of int64 values, or
+// the fallback.
+func GetInt64SliceOr(key string, fallback []int64) []int64 {
+	if val, ok := LookupInt64Slice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetFloat64SliceOr returns the environment variable as a comma-separated list of float64 values,
+// or the fallback.
+func GetFloat64SliceOr(key string, fallback []float64) []float64 {
+	if val, ok := LookupFloat64Slice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetDurationSliceOr returns the environment variable as a comma-separated list of durations, or
+// the fallback.
+func GetDurationSliceOr(key string, fallback []time.Duration) []time.Duration {
+	if val, ok := LookupDurationSlice(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetStringMapOr returns the environment variable as a map, or the fallback.
+func GetStringMapOr(key string, fallback map[string]string) map[string]string {
+	if val, ok := LookupStringMap(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetBase64Or returns the environment variable as base64-encoded binary data, or the fallback.
+func GetBase64Or(key string, fallback []byte) []byte {
+	if val, ok := LookupBase64(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetHexOr returns the environment variable as hex-encoded binary data, or the fallback.
+func GetHexOr(key string, fallback []byte) []byte {
+	if val, ok := LookupHex(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetRegexpOr returns the environment variable as a regular expression, or the fallback.
+func GetRegexpOr(key string, fallback *regexp.Regexp) *regexp.Regexp {
+	if val, ok := LookupRegexp(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetEnumOr returns the environment variable as written in the allowed values, or the fallback.
+func GetEnumOr(key, fallback string, allowed ...string) string {
+	if val, ok := LookupEnum(key, allowed...); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetLogLevelOr returns the environment variable as a slog.Level, or the fallback.
+func GetLogLevelOr(key string, fallback slog.Level) slog.Level {
+	if val, ok := LookupLogLevel(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetTimeZoneOr returns the environment variable as a time zone, or the fallback.
+func GetTimeZoneOr(key string, fallback *time.Location) *time.Location {
+	if val, ok := LookupTimeZone(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetPortOr returns the environment variable as a port number, or the fallback.
+func GetPortOr(key string, fallback int) int {
+	if val, ok := LookupPort(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetCSVOr returns the environment variable parsed as a single CSV record, or the fallback.
+func GetCSVOr(key string, fallback []string) []string {
+	if val, ok := LookupCSV(key); ok {
+		return val
+	}
+
+	return fallback
+}
+
+// GetPercentOr returns the environment variable as a fraction between 0 and 1, or the fallback.
+func GetPercentOr(key string, fallback float64) float64 {
+	if val, ok := LookupPercent(key); ok {
+		return val
+	}
+
+	return fallback
+}
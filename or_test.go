@@ -0,0 +1,78 @@
+package dotenv
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrPrecedence(t *testing.T) {
+	unsetenv(t, "OR_UNSET_NAME", "OR_UNSET_WORKERS", "OR_UNSET_DEBUG")
+	t.Setenv("OR_NAME", "env")
+	t.Setenv("OR_WORKERS", "8")
+	t.Setenv("OR_DEBUG", "true")
+	t.Setenv("OR_BAD_WORKERS", "eight")
+	t.Setenv("OR_BAD_DEBUG", "yes")
+
+	// the fallback takes precedence over a registered default, but not the environment
+	for _, key := range []string{"OR_NAME", "OR_UNSET_NAME"} {
+		Register(key, "default", "A name")
+	}
+	for _, key := range []string{"OR_WORKERS", "OR_UNSET_WORKERS", "OR_BAD_WORKERS"} {
+		Register(key, 2, "Workers")
+	}
+	for _, key := range []string{"OR_DEBUG", "OR_UNSET_DEBUG", "OR_BAD_DEBUG"} {
+		Register(key, true, "Debug")
+	}
+
+	names := []struct {
+		key, expected string
+	}{
+		{key: "OR_NAME", expected: "env"},
+		{key: "OR_UNSET_NAME", expected: "fallback"},
+	}
+	for _, test := range names {
+		if val := GetStringOr(test.key, "fallback"); val != test.expected {
+			t.Errorf("GetStringOr(%s): expected %q; got %q", test.key, test.expected, val)
+		}
+	}
+
+	ints := []struct {
+		key      string
+		expected int
+	}{
+		{key: "OR_WORKERS", expected: 8},
+		{key: "OR_UNSET_WORKERS", expected: 4},
+		{key: "OR_BAD_WORKERS", expected: 4},
+	}
+	for _, test := range ints {
+		if val := GetIntOr(test.key, 4); val != test.expected {
+			t.Errorf("GetIntOr(%s): expected %d; got %d", test.key, test.expected, val)
+		}
+	}
+
+	bools := []struct {
+		key      string
+		expected bool
+	}{
+		{key: "OR_DEBUG", expected: true},
+		{key: "OR_UNSET_DEBUG", expected: false},
+		{key: "OR_BAD_DEBUG", expected: false},
+	}
+	for _, test := range bools {
+		if val := GetBoolOr(test.key, false); val != test.expected {
+			t.Errorf("GetBoolOr(%s): expected %v; got %v", test.key, test.expected, val)
+		}
+	}
+}
+
+func TestOrWithoutRegistering(t *testing.T) {
+	unsetenv(t, "OR_ONE_OFF")
+
+	if val := GetDurationOr("OR_ONE_OFF", time.Second); val != time.Second {
+		t.Errorf("expected the fallback; got %s", val)
+	}
+
+	if _, ok := Default("OR_ONE_OFF"); ok {
+		t.Error("expected the Or functions not to register a default")
+	}
+}
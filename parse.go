@@ -0,0 +1,886 @@
+package dotenv
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// The longest line supported in a .env file, in bytes.
+const maxLineSize = 8 * 1024 * 1024
+
+// Identifies settings parsed from an io.Reader in any errors.
+const readerName = "<reader>"
+
+// How deeply "source" or "#include" directives may be nested.
+const maxIncludeDepth = 10
+
+// A single assignment parsed from a .env file.  If Unset is true, the variable is removed from the
+// environment instead.
+type assignment struct {
+	Key   string
+	Value string
+	Unset bool
+	File  string
+	Line  int
+}
+
+// ParseError describes a problem with a line in a .env file.
+type ParseError struct {
+	Filename string
+	Line     int
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s %s:%d", e.Err, e.Filename, e.Line)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors collects every problem found in a .env file, so they may all be fixed at once.  Each
+// error is a *ParseError.
+type ParseErrors []error
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e ParseErrors) Unwrap() []error {
+	return e
+}
+
+// Parses the .env file format.  Tracks the values assigned so far so later lines may reference
+// earlier ones, the chain of included files, and any problems found along the way.
+type parser struct {
+	fsys    fs.FS
+	env     func(string) (string, bool)
+	name    string
+	opts    Options
+	vars    map[string]string
+	removed map[string]bool
+	seen    map[string]position
+	lists   map[string]*indexedList
+	chain   []string
+	errs    ParseErrors
+}
+
+// Where a key was first assigned, shared with any included files so duplicates are found across
+// them.
+type position struct {
+	file string
+	line int
+}
+
+// Describe where the key was first assigned, relative to the file being parsed, e.g. "line 3" or
+// "base.env:3".
+func (p *parser) where(pos position) string {
+	if pos.file == p.name {
+		return fmt.Sprintf("line %d", pos.line)
+	}
+
+	return fmt.Sprintf("%s:%d", pos.file, pos.line)
+}
+
+// Passed to parseWith to read included files from the OS filesystem.  Only settings read from a
+// file on disk may include other files this way.  Settings from a reader, stdin, or URL can't
+// include files at all, so content from elsewhere can't read local files.
+var osFiles fs.FS = osFS{}
+
+// Opens files from the OS filesystem, using OS paths.
+type osFS struct{}
+
+func (osFS) Open(name string) (fs.File, error) {
+	return os.Open(name)
+}
+
+// The values assigned to an indexed key, e.g. KEY[0]=a, by index, along with the line each was
+// assigned on.  The line is where the key was first assigned.
+type indexedList struct {
+	line   int
+	values map[int]string
+	lines  map[int]int
+}
+
+// Parse reads settings in the .env file format from r and returns them as a map, without modifying
+// the environment.  The format is the same one used by Load.  If any lines are invalid, returns
+// ParseErrors describing every problem, referencing the line number in the reader.  An "unset KEY"
+// directive removes the key from the map.  Settings from a reader can't include other files.
+func Parse(r io.Reader) (map[string]string, error) {
+	assignments, err := parse(r, readerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return settingsMap(assignments), nil
+}
+
+// ParseFile reads the settings from the .env file and returns them as a map, without modifying the
+// environment.
+func ParseFile(filename string) (map[string]string, error) {
+	assignments, err := parseFile(filename, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return settingsMap(assignments), nil
+}
+
+// Collect the settings into a map, with later assignments replacing earlier ones.
+func settingsMap(assignments []assignment) map[string]string {
+	settings := make(map[string]string, len(assignments))
+	for _, a := range assignments {
+		if a.Unset {
+			delete(settings, a.Key)
+		} else {
+			settings[a.Key] = a.Value
+		}
+	}
+
+	return settings
+}
+
+// Parse the settings in r in order.  The name identifies the source of the settings in any errors,
+// typically the filename.  Parsing continues past any problems, which are returned together as
+// ParseErrors.
+func parse(r io.Reader, name string) ([]assignment, error) {
+	return parseWith(nil, nil, r, name)
+}
+
+// Parse the settings in r in order, like parse, but resolve any included files in fsys and look up
+// existing variables with env.  Pass osFiles to read included files from the OS filesystem.  If
+// fsys is nil, any "source" or "#include" directive is an error.  If env is nil, variables are
+// looked up in the environment.
+func parseWith(fsys fs.FS, env func(string) (string, bool), r io.Reader, name string) ([]assignment, error) {
+	return newParser(fsys, env, name).run(r)
+}
+
+// Create a parser for the settings named name, per parseWith.
+func newParser(fsys fs.FS, env func(string) (string, bool), name string) *parser {
+	if env == nil {
+		env = os.LookupEnv
+	}
+
+	// Leave URLs alone, e.g. from LoadURL, as cleaning would collapse the "//"
+	switch {
+	case strings.Contains(name, "://"):
+	case fsys != nil && fsys != osFiles:
+		name = path.Clean(name)
+	default:
+		name = filepath.Clean(name)
+	}
+
+	return &parser{
+		fsys:    fsys,
+		env:     env,
+		name:    name,
+		opts:    currentOptions(),
+		vars:    make(map[string]string),
+		removed: make(map[string]bool),
+		seen:    make(map[string]position),
+		lists:   make(map[string]*indexedList),
+		chain:   []string{name},
+	}
+}
+
+// Parse the settings in r, returning ParseErrors describing every problem found.
+func (p *parser) run(r io.Reader) ([]assignment, error) {
+	assignments := p.parse(r)
+	if len(p.errs) > 0 {
+		return nil, p.errs
+	}
+
+	return assignments, nil
+}
+
+// Parse the settings in r in order, recording any problems in p.errs.
+func (p *parser) parse(r io.Reader) []assignment {
+	var assignments []assignment
+
+	r, err := decodeUTF16(r)
+	if err != nil {
+		p.fail(1, fmt.Errorf("failed to read %s", err))
+		return nil
+	}
+
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	lineNo, nextLine := 0, 1
+
+	for s.Scan() {
+		line := s.Text()
+		lineNo, nextLine = nextLine, nextLine+1
+
+		// editors such as Notepad may save the file with a UTF-8 byte order mark
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		// settings in the included file may be overridden by those that follow
+		if target, ok := includeDirective(trimmed); ok {
+			assignments = append(assignments, p.include(target, lineNo)...)
+			continue
+		}
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if keys, ok := unsetDirective(trimmed); ok {
+			for _, key := range keys {
+				key, err := checkKey(key, p.opts)
+				if err != nil {
+					p.fail(lineNo, err)
+					continue
+				}
+
+				delete(p.vars, key)
+				p.removed[key] = true
+
+				assignments = append(assignments, assignment{Key: key, Unset: true, File: p.name, Line: lineNo})
+			}
+
+			continue
+		}
+
+		// lines such as "export FOO" without an assignment are skipped below
+		stripped := trimKeyword(line)
+		prefixed := stripped != line
+		line = stripped
+
+		var key, op, value string
+
+		if name, terminator, ok := heredoc(line); ok {
+			// the heredoc body is taken verbatim, up to the terminator line
+			var body []string
+			terminated := false
+
+			for !terminated && s.Scan() {
+				nextLine++
+
+				if text := s.Text(); strings.TrimRight(text, " \t\r") == terminator {
+					terminated = true
+				} else {
+					body = append(body, text)
+				}
+			}
+
+			if !terminated {
+				p.fail(lineNo, fmt.Errorf("unterminated heredoc, expected %s", terminator))
+				continue
+			}
+
+			key, op, value = name, "=", strings.Join(body, "\n")
+		} else {
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 {
+				if !p.opts.AllowBareKeys || prefixed {
+					// a line such as "export FOO", with no assignment, is ignored and sets nothing
+					// rather than error out, simply skip this line...
+					// return fmt.Errorf("unable to parse line %s:%d", filename, lineNo)
+					continue
+				}
+
+				bare := strings.TrimSpace(line)
+				if strings.ContainsAny(bare, " \t") {
+					p.fail(lineNo, fmt.Errorf("invalid line %q", bare))
+					continue
+				}
+
+				// a bare key such as ENABLE_TRACING is a flag, i.e. ENABLE_TRACING=true
+				parts = []string{bare, "true"}
+			}
+
+			// double-quoted values may span multiple lines; errors are reported on the first line
+			raw := parts[1]
+			for unterminated(raw) && s.Scan() {
+				raw += "\n" + s.Text()
+				nextLine++
+			}
+
+			// unquoted values ending in a backslash continue on the next line
+			for continued(raw) && s.Scan() {
+				raw = strings.TrimSuffix(strings.TrimRight(raw, " \t"), `\`) + s.Text()
+				nextLine++
+			}
+
+			parsed, err := p.parseValue(raw)
+			if err != nil {
+				p.fail(lineNo, err)
+				continue
+			}
+
+			key, op = splitOperator(parts[0])
+			value = parsed
+		}
+
+		key, index, indexed := splitIndex(key)
+
+		// empty values are allowed, e.g. FOO=, to explicitly clear a variable
+		key, err := checkKey(key, p.opts)
+		if err != nil {
+			p.fail(lineNo, err)
+			continue
+		}
+
+		// KEY[0]=a, KEY[1]=b, etc. are collapsed into KEY=a,b
+		if indexed {
+			joined, err := p.assignIndex(key, index, op, value, lineNo)
+			if err != nil {
+				p.fail(lineNo, err)
+				continue
+			}
+
+			p.vars[key] = joined
+			delete(p.removed, key)
+
+			assignments = append(assignments, assignment{Key: key, Value: joined, File: p.name, Line: lineNo})
+			continue
+		}
+
+		if list, ok := p.lists[key]; ok {
+			p.fail(lineNo, fmt.Errorf("%s is also assigned by index on line %d", key, list.line))
+			continue
+		}
+
+		// KEY ?= value only assigns the value if the variable isn't already set
+		if op == "?=" {
+			if _, set := p.lookup(key); set {
+				continue
+			}
+		}
+
+		// KEY += value appends to the current value
+		if op == "+=" {
+			if current, set := p.lookup(key); set && current != "" {
+				value = current + p.opts.AppendSeparator + value
+			}
+		}
+
+		if first, ok := p.seen[key]; ok && op != "+=" && p.opts.ErrOnDuplicateKeys {
+			p.fail(lineNo, fmt.Errorf("duplicate key %s (first assigned on %s)", key, p.where(first)))
+			continue
+		}
+		p.seen[key] = position{file: p.name, line: lineNo}
+		p.vars[key] = value
+		delete(p.removed, key)
+
+		assignments = append(assignments, assignment{Key: key, Value: value, File: p.name, Line: lineNo})
+	}
+
+	if err := s.Err(); err != nil {
+		p.fail(nextLine, fmt.Errorf("failed to read %s", err))
+	}
+
+	return assignments
+}
+
+// Assign a value to the indexed key, e.g. KEY[2]=value, and return all the values assigned to the
+// key so far, in index order and separated by commas.  Any gaps in the indexes are ignored.
+func (p *parser) assignIndex(key string, index int, op, value string, line int) (string, error) {
+	if op != "=" {
+		return "", fmt.Errorf("the %s operator isn't supported with indexed keys", op)
+	}
+
+	if first, ok := p.seen[key]; ok {
+		return "", fmt.Errorf("%s[%d] conflicts with %s assigned on %s", key, index, key, p.where(first))
+	}
+
+	list, ok := p.lists[key]
+	if !ok {
+		list = &indexedList{line: line, values: make(map[int]string), lines: make(map[int]int)}
+		p.lists[key] = list
+	}
+
+	if first, ok := list.lines[index]; ok && p.opts.ErrOnDuplicateKeys {
+		return "", fmt.Errorf("duplicate key %s[%d] (first assigned on line %d)", key, index, first)
+	}
+
+	list.values[index] = value
+	list.lines[index] = line
+
+	indexes := make([]int, 0, len(list.values))
+	for i := range list.values {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	values := make([]string, len(indexes))
+	for i, idx := range indexes {
+		values[i] = list.values[idx]
+	}
+
+	return strings.Join(values, ","), nil
+}
+
+// Parse the settings from an included file.  The target is relative to the directory of the
+// including file.
+func (p *parser) include(target string, line int) []assignment {
+	switch {
+	case p.fsys == nil:
+		p.fail(line, fmt.Errorf("unable to include %s; only files on disk may include other files", target))
+		return nil
+	case p.fsys != osFiles:
+		target = path.Join(path.Dir(p.name), target)
+	case !filepath.IsAbs(target):
+		target = filepath.Join(filepath.Dir(p.name), target)
+	default:
+		target = filepath.Clean(target)
+	}
+
+	chain := append(append([]string{}, p.chain...), target)
+
+	for _, name := range p.chain {
+		if name == target {
+			p.fail(line, fmt.Errorf("include cycle %s", strings.Join(chain, " -> ")))
+			return nil
+		}
+	}
+
+	if len(p.chain) > maxIncludeDepth {
+		p.fail(line, fmt.Errorf("includes nested more than %d deep (%s)", maxIncludeDepth, strings.Join(chain, " -> ")))
+		return nil
+	}
+
+	file, err := p.fsys.Open(target)
+	if err != nil {
+		p.fail(line, fmt.Errorf("unable to include %s", err))
+		return nil
+	}
+	defer file.Close()
+
+	included := &parser{
+		fsys:    p.fsys,
+		env:     p.env,
+		name:    target,
+		opts:    p.opts,
+		vars:    p.vars,
+		removed: p.removed,
+		seen:    p.seen,
+		lists:   make(map[string]*indexedList),
+		chain:   chain,
+	}
+
+	assignments := included.parse(file)
+	p.errs = append(p.errs, included.errs...)
+
+	return assignments
+}
+
+// Split an indexed key such as KEY[2] into the key and the index.
+func splitIndex(key string) (string, int, bool) {
+	open := strings.Index(key, "[")
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return key, 0, false
+	}
+
+	index, err := strconv.Atoi(key[open+1 : len(key)-1])
+	if err != nil || index < 0 {
+		return key, 0, false
+	}
+
+	return key[:open], index, true
+}
+
+// Split the key portion of an assignment from its operator.  Returns "=" for a regular assignment,
+// "?=" to only set the variable if it isn't already set, or "+=" to append to the current value.
+func splitOperator(raw string) (string, string) {
+	key := strings.TrimSpace(raw)
+	for _, op := range []string{"?", "+"} {
+		if strings.HasSuffix(key, op) {
+			return strings.TrimSpace(strings.TrimSuffix(key, op)), op + "="
+		}
+	}
+
+	return key, "="
+}
+
+// Look up the current value of a variable, either assigned earlier in the file or from the
+// environment.
+func (p *parser) lookup(key string) (string, bool) {
+	if p.opts.CaseInsensitiveKeys {
+		key = strings.ToUpper(key)
+	}
+
+	if val, ok := p.vars[key]; ok {
+		return val, true
+	}
+
+	if p.removed[key] {
+		return "", false
+	}
+
+	return p.env(key)
+}
+
+// Normalize the key, if configured, and confirm it's a valid environment variable name.
+func checkKey(key string, opts Options) (string, error) {
+	if opts.NormalizeKeys {
+		key = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(key))
+	} else if opts.CaseInsensitiveKeys {
+		key = strings.ToUpper(key)
+	}
+
+	if key == "" {
+		return "", errors.New("invalid environment variable assignment")
+	}
+
+	if !opts.AllowInvalidKeys && !validKey(key) {
+		return "", fmt.Errorf("invalid environment variable name %q", key)
+	}
+
+	return key, nil
+}
+
+// Is the key a valid POSIX environment variable name, i.e. [A-Za-z_][A-Za-z0-9_]*?
+func validKey(key string) bool {
+	for i, c := range key {
+		switch {
+		case c == '_', c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return key != ""
+}
+
+// Windows tools may save files as UTF-16.  If the reader starts with a UTF-16 byte order mark, or
+// looks like little-endian UTF-16 text, returns a reader of the content converted to UTF-8.
+// Otherwise the content is returned as is.
+func decodeUTF16(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+
+	head, err := br.Peek(2)
+	if err != nil || len(head) < 2 {
+		return br, nil
+	}
+
+	var order binary.ByteOrder
+
+	switch {
+	case head[0] == 0xff && head[1] == 0xfe:
+		order = binary.LittleEndian
+	case head[0] == 0xfe && head[1] == 0xff:
+		order = binary.BigEndian
+	case head[0] != 0 && head[1] == 0:
+		order = binary.LittleEndian
+	default:
+		return br, nil
+	}
+
+	data, err := io.ReadAll(br)
+	if err != nil {
+		return nil, err
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[i*2:])
+	}
+
+	// the byte order mark becomes \ufeff, which is stripped from the first line
+	return strings.NewReader(string(utf16.Decode(units))), nil
+}
+
+// Record a problem with the given line and continue parsing.
+func (p *parser) fail(line int, err error) {
+	p.errs = append(p.errs, &ParseError{Filename: p.name, Line: line, Err: err})
+}
+
+// Escape sequences recognized in double-quoted values.  Any other escape, such as \q, is left as
+// is, backslash included, so "x\qy" reads as x\qy.
+var escapes = map[byte]byte{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'\\': '\\',
+	'"':  '"',
+	'$':  '$',
+}
+
+// Is the line a "source other.env" or "#include other.env" directive?  Returns the file to include.
+// As "#include" is also a comment, it must be followed by a single path, or a quoted one, so a
+// comment such as "#include the API key below" is left alone.
+func includeDirective(trimmed string) (string, bool) {
+	for _, directive := range []string{"source ", "source\t", "#include "} {
+		if !strings.HasPrefix(trimmed, directive) {
+			continue
+		}
+
+		target := strings.TrimSpace(trimmed[len(directive):])
+		if target == "" || strings.HasPrefix(target, "=") {
+			return "", false
+		}
+
+		if len(target) >= 2 && (target[0] == '"' || target[0] == '\'') && target[len(target)-1] == target[0] {
+			return target[1 : len(target)-1], true
+		}
+
+		if directive == "#include " && strings.ContainsAny(target, " \t") {
+			return "", false
+		}
+
+		return strings.Trim(target, `"'`), true
+	}
+
+	return "", false
+}
+
+// Is the line an "unset KEY" directive?  Returns the keys to remove from the environment.
+func unsetDirective(trimmed string) ([]string, bool) {
+	fields := strings.Fields(trimmed)
+	if len(fields) < 2 || fields[0] != "unset" || strings.HasPrefix(fields[1], "=") {
+		return nil, false
+	}
+
+	return fields[1:], true
+}
+
+// Is the line the start of a heredoc, e.g. "KEY<<EOF"?  Returns the key and the terminator.
+func heredoc(line string) (string, string, bool) {
+	idx := strings.Index(line, "<<")
+	if idx == -1 || strings.Contains(line[:idx], "=") {
+		return "", "", false
+	}
+
+	terminator := strings.TrimSpace(line[idx+2:])
+	if terminator == "" || strings.ContainsAny(terminator, " \t") {
+		return "", "", false
+	}
+
+	return strings.TrimSpace(line[:idx]), terminator, true
+}
+
+// Strip a leading "export " from the line, so .env files may double as shell scripts, or "set "
+// for lines copied from the Windows command prompt.
+func trimKeyword(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+	for _, keyword := range []string{"export", "set"} {
+		if strings.HasPrefix(trimmed, keyword+" ") || strings.HasPrefix(trimmed, keyword+"\t") {
+			return strings.TrimLeft(trimmed[len(keyword):], " \t")
+		}
+	}
+
+	return line
+}
+
+// Parse the value portion of an assignment, i.e. everything after the "=".  Quoted values are taken
+// verbatim, without the quotes; unquoted values are trimmed and any trailing comment is removed.
+// Single-quoted values are always literal, while double-quoted and unquoted values have any
+// ${VAR} references expanded.  Double-quoted values also support escape sequences such as \n.
+func (p *parser) parseValue(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+
+	if strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, "'") {
+		quote := trimmed[0]
+
+		end := closingQuote(trimmed, quote)
+		if end == -1 {
+			return "", errors.New("unterminated quoted value")
+		}
+
+		if rest := strings.TrimSpace(trimmed[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", errors.New("unexpected characters after quoted value")
+		}
+
+		if quote == '\'' {
+			return trimmed[1:end], nil
+		}
+
+		return p.expand(trimmed[1:end], true)
+	}
+
+	return p.expand(strings.TrimSpace(stripComment(raw)), false)
+}
+
+// Remove any trailing comment from an unquoted value.  A "#" only starts a comment when preceded by
+// whitespace, so values such as passwords and URLs with fragments may contain a "#".
+func stripComment(raw string) string {
+	for i := 1; i < len(raw); i++ {
+		if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+			return raw[:i]
+		}
+	}
+
+	return raw
+}
+
+// Find the closing quote in a quoted value, skipping over escaped quotes in double-quoted values.
+// Returns -1 if the value is unterminated.
+func closingQuote(raw string, quote byte) int {
+	for i := 1; i < len(raw); i++ {
+		switch {
+		case raw[i] == '\\' && quote == '"':
+			i++
+		case raw[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Is the raw value a double-quoted value missing its closing quote?
+func unterminated(raw string) bool {
+	raw = strings.TrimSpace(raw)
+	return strings.HasPrefix(raw, `"`) && closingQuote(raw, '"') == -1
+}
+
+// Does the raw, unquoted value end in a backslash, continuing the value on the next line?  A
+// backslash inside a trailing comment doesn't count.
+func continued(raw string) bool {
+	trimmed := strings.TrimSpace(raw)
+	if strings.HasPrefix(trimmed, `"`) || strings.HasPrefix(trimmed, "'") {
+		return false
+	}
+
+	return strings.HasSuffix(trimmed, `\`) && strings.HasSuffix(strings.TrimSpace(stripComment(raw)), `\`)
+}
+
+// Expand any ${VAR} references in the value with the value assigned earlier in the file, or the
+// current value of the environment variable.  Undefined variables expand to a blank string, unless
+// a fallback is supplied using ${VAR:-fallback}.  Use ${VAR:?message} to require the variable be
+// set, or \${VAR} to leave the reference as is.
+//
+// If command substitution is enabled, $(command) is replaced with the output of the command; use
+// \$(command) to leave it as is.  Nested substitutions aren't supported.
+//
+// If unescape is true, escape sequences such as \n, \", and \$ are converted as well, while unknown
+// escapes keep their backslash.  Only ${ starts a reference, so values such as bcrypt hashes
+// ($2a$10$...) are preserved without escaping.
+func (p *parser) expand(value string, unescape bool) (string, error) {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c == '\\' && unescape && i+1 < len(value) {
+			if r, ok := escapes[value[i+1]]; ok {
+				b.WriteByte(r)
+				i++
+				continue
+			}
+		}
+
+		if c == '\\' && (strings.HasPrefix(value[i+1:], "${") || strings.HasPrefix(value[i+1:], "$(")) {
+			b.WriteString(value[i+1 : i+3])
+			i += 2
+			continue
+		}
+
+		if c == '$' && p.opts.AllowCommandSubstitution && strings.HasPrefix(value[i+1:], "(") {
+			end := strings.Index(value[i+2:], ")")
+			if end == -1 {
+				return "", errors.New("unterminated command substitution")
+			}
+
+			output, err := substitute(value[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(output)
+			i += 2 + end
+			continue
+		}
+
+		if c == '$' && strings.HasPrefix(value[i+1:], "{") {
+			end := strings.Index(value[i+2:], "}")
+			if end == -1 {
+				return "", errors.New("unterminated variable reference")
+			}
+
+			expanded, err := p.lookupRef(value[i+2 : i+2+end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(expanded)
+			i += 2 + end
+			continue
+		}
+
+		b.WriteByte(c)
+	}
+
+	return b.String(), nil
+}
+
+// Run the command in a $(...) substitution with the shell and return its trimmed output.
+func substitute(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	}
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("command $(%s) failed: %s: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// Look up the value of a variable reference, i.e. the "VAR", "VAR:-fallback", or "VAR:?message"
+// portion of a ${...} expression.
+func (p *parser) lookupRef(ref string) (string, error) {
+	name, op, arg := ref, "", ""
+	if idx := strings.Index(ref, ":"); idx != -1 {
+		name, op = ref[:idx], ref[idx:]
+		if len(op) >= 2 {
+			op, arg = op[:2], op[2:]
+		}
+	}
+
+	val, _ := p.lookup(name)
+
+	switch op {
+	case "":
+		return val, nil
+	case ":-":
+		if val == "" {
+			return arg, nil
+		}
+
+		return val, nil
+	case ":?":
+		if val == "" {
+			if arg == "" {
+				arg = "not set"
+			}
+
+			return "", fmt.Errorf("%s: %s", name, arg)
+		}
+
+		return val, nil
+	default:
+		return "", fmt.Errorf("invalid variable reference ${%s}", ref)
+	}
+}
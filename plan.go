@@ -0,0 +1,75 @@
+package dotenv
+
+import "fmt"
+
+// Change describes how loading a .env file would change an environment variable.
+type Change struct {
+	Key     string
+	Current string
+	WasSet  bool
+	Value   string
+	Unset   bool
+	File    string
+	Line    int
+}
+
+// String describes the change, e.g. "PORT: 8080 -> 9090 (.env:3)".  The values of variables
+// registered with RegisterSecret are replaced by "(redacted)".
+func (c Change) String() string {
+	current, value := c.Current, c.Value
+	if descriptor, ok := Default(c.Key); ok && descriptor.Secret {
+		current, value = redacted, redacted
+	}
+
+	if !c.WasSet {
+		current = "(not set)"
+	}
+
+	if c.Unset {
+		value = "(unset)"
+	}
+
+	source := c.File
+	if c.Line > 0 {
+		source = fmt.Sprintf("%s:%d", c.File, c.Line)
+	}
+
+	return fmt.Sprintf("%s: %s -> %s (%s)", c.Key, current, value, source)
+}
+
+// Plan reports how loading the files would change the environment, without changing anything.
+// The files are parsed exactly as LoadFiles would, so later files see the values planned by
+// earlier ones.  If no files are given, plans the files loaded by Load.  Variables that would be
+// assigned the value they already have aren't reported.
+func Plan(filenames ...string) ([]Change, error) {
+	l := newLoader()
+	l.dryRun = true
+
+	if len(filenames) == 0 {
+		err := load(l)
+		return l.changes, err
+	}
+
+	err := l.processFiles(filenames)
+	return l.changes, err
+}
+
+// Record the change the assignment would make.
+func (l *loader) plan(a assignment) {
+	current, set := l.lookup(a.Key)
+
+	if a.Unset {
+		if set {
+			l.changes = append(l.changes, Change{Key: a.Key, Current: current, WasSet: true, Unset: true, File: a.File, Line: a.Line})
+		}
+
+		l.remember(a)
+		return
+	}
+
+	if !set || current != a.Value {
+		l.changes = append(l.changes, Change{Key: a.Key, Current: current, WasSet: set, Value: a.Value, File: a.File, Line: a.Line})
+	}
+
+	l.remember(a)
+}
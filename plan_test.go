@@ -0,0 +1,43 @@
+package dotenv
+
+import "testing"
+
+func TestChangeString(t *testing.T) {
+	RegisterSecret("PLAN_PASSWORD", "", "A password")
+
+	tests := []struct {
+		change   Change
+		expected string
+	}{
+		{
+			change:   Change{Key: "PLAN_PORT", Current: "8080", WasSet: true, Value: "9090", File: ".env", Line: 3},
+			expected: "PLAN_PORT: 8080 -> 9090 (.env:3)",
+		},
+		{
+			change:   Change{Key: "PLAN_PORT", Value: "9090", File: ".env", Line: 3},
+			expected: "PLAN_PORT: (not set) -> 9090 (.env:3)",
+		},
+		{
+			change:   Change{Key: "PLAN_PORT", Current: "8080", WasSet: true, Unset: true, File: ".env"},
+			expected: "PLAN_PORT: 8080 -> (unset) (.env)",
+		},
+		{
+			change:   Change{Key: "PLAN_PASSWORD", Current: "hunter2", WasSet: true, Value: "s3cret", File: ".env", Line: 1},
+			expected: "PLAN_PASSWORD: (redacted) -> (redacted) (.env:1)",
+		},
+		{
+			change:   Change{Key: "PLAN_PASSWORD", Value: "s3cret", File: ".env", Line: 1},
+			expected: "PLAN_PASSWORD: (not set) -> (redacted) (.env:1)",
+		},
+		{
+			change:   Change{Key: "PLAN_PASSWORD", Current: "hunter2", WasSet: true, Unset: true, File: ".env", Line: 1},
+			expected: "PLAN_PASSWORD: (redacted) -> (unset) (.env:1)",
+		},
+	}
+
+	for _, test := range tests {
+		if s := test.change.String(); s != test.expected {
+			t.Errorf("expected %q; got %q", test.expected, s)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package dotenv
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Port is a TCP or UDP port number.  Register a Port default for a setting read by GetPort, and
+// Help displays it as a port.
+type Port int
+
+// GetPort returns the environment variable as a port number, between 1 and 65535, or 0 as well if
+// the AllowZeroPort option is set.  If the environment variable doesn't exist or isn't a valid
+// port, returns the default value if present, which may be registered as a Port or int, otherwise
+// returns 0.
+func GetPort(key string) int {
+	if port, err := GetPortE(key); err == nil {
+		return port
+	}
+
+	return defaultPort(key)
+}
+
+// GetPortE returns the environment variable as a port number, like GetPort, but returns a
+// *ParseValueError if the environment variable is set to anything other than a valid port, rather
+// than falling back to the default.
+func GetPortE(key string) (int, error) {
+	if val, set := lookupEnv(key); set {
+		port, err := parsePort(val)
+		if err != nil {
+			return 0, &ParseValueError{Key: key, Raw: val, Type: typeNames[PortType], Err: err}
+		}
+
+		return port, nil
+	}
+
+	return defaultPort(key), nil
+}
+
+// Parse the port number, checking it's in range.
+func parsePort(val string) (int, error) {
+	port, err := strconv.Atoi(strings.TrimSpace(val))
+	if err != nil {
+		return 0, err
+	}
+
+	lowest := 1
+	if currentOptions().AllowZeroPort {
+		lowest = 0
+	}
+
+	if port < lowest || port > 65535 {
+		return 0, fmt.Errorf("must be between %d and 65535", lowest)
+	}
+
+	return port, nil
+}
+
+// Returns the registered default port, or 0.
+func defaultPort(key string) int {
+	if descriptor, ok := Default(key); ok {
+		switch defaultValue := descriptor.DefaultValue.(type) {
+		case Port:
+			return int(defaultValue)
+		case int:
+			return defaultValue
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,75 @@
+package dotenv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetPort(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected int
+		valid    bool
+	}{
+		{val: "8080", expected: 8080, valid: true},
+		{val: " 443 ", expected: 443, valid: true},
+		{val: "1", expected: 1, valid: true},
+		{val: "65535", expected: 65535, valid: true},
+		{val: "0"},
+		{val: "65536"},
+		{val: "-80"},
+		{val: "http"},
+		{val: ""},
+	}
+
+	Register("PORT_TEST", Port(3000), "The port for GetPort")
+
+	for _, test := range tests {
+		t.Setenv("PORT_TEST", test.val)
+
+		port, err := GetPortE("PORT_TEST")
+		if test.valid {
+			if err != nil || port != test.expected {
+				t.Errorf("%q: expected %d; got %d, %v", test.val, test.expected, port, err)
+			}
+
+			continue
+		}
+
+		var perr *ParseValueError
+		if !errors.As(err, &perr) {
+			t.Errorf("%q: expected a *ParseValueError; got %d, %v", test.val, port, err)
+		}
+
+		if port := GetPort("PORT_TEST"); port != 3000 {
+			t.Errorf("%q: expected GetPort to fall back to the default; got %d", test.val, port)
+		}
+	}
+}
+
+func TestGetPortDefault(t *testing.T) {
+	unsetenv(t, "PORT_DEFAULT", "PORT_INT_DEFAULT", "PORT_MISSING")
+	Register("PORT_DEFAULT", Port(8080), "A Port default")
+	Register("PORT_INT_DEFAULT", 9090, "An int default")
+
+	if port := GetPort("PORT_DEFAULT"); port != 8080 {
+		t.Errorf("expected the Port default; got %d", port)
+	}
+
+	if port := GetPort("PORT_INT_DEFAULT"); port != 9090 {
+		t.Errorf("expected the int default; got %d", port)
+	}
+
+	if port, err := GetPortE("PORT_MISSING"); err != nil || port != 0 {
+		t.Errorf("expected 0 without a default; got %d, %v", port, err)
+	}
+}
+
+func TestGetPortAllowZero(t *testing.T) {
+	setOptions(t, Options{AllowZeroPort: true})
+	t.Setenv("PORT_ZERO", "0")
+
+	if port, err := GetPortE("PORT_ZERO"); err != nil || port != 0 {
+		t.Errorf("expected port 0 to be allowed; got %d, %v", port, err)
+	}
+}
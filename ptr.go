@@ -0,0 +1,58 @@
+package dotenv
+
+import "time"
+
+// The Ptr functions return nil if the environment variable isn't set to a valid value and has no
+// default, for optional settings where the zero value is meaningful, e.g. MAX_IDLE_CONNS=0.  The
+// pointers suit configuration structs with optional fields.
+
+// GetStringPtr returns a pointer to the environment variable, like GetString, or nil if it isn't
+// set and has no default.
+func GetStringPtr(key string) *string {
+	return getPtr(key, LookupString, GetString)
+}
+
+// GetIntPtr returns a pointer to the environment variable as an integer, like GetInt, or nil if it
+// isn't set to an integer and has no default.
+func GetIntPtr(key string) *int {
+	return getPtr(key, LookupInt, GetInt)
+}
+
+// GetInt64Ptr returns a pointer to the environment variable as an int64, like GetInt64, or nil if
+// it isn't set to an integer and has no default.
+func GetInt64Ptr(key string) *int64 {
+	return getPtr(key, LookupInt64, GetInt64)
+}
+
+// GetFloat64Ptr returns a pointer to the environment variable as a float64, like GetFloat64, or nil
+// if it isn't set to a number and has no default.
+func GetFloat64Ptr(key string) *float64 {
+	return getPtr(key, LookupFloat64, GetFloat64)
+}
+
+// GetBoolPtr returns a pointer to the environment variable as a boolean, like GetBool, or nil if
+// it isn't set to "true" or "false" and has no default.
+func GetBoolPtr(key string) *bool {
+	return getPtr(key, LookupBool, GetBool)
+}
+
+// GetDurationPtr returns a pointer to the environment variable as a duration, like GetDuration, or
+// nil if it isn't set to a duration and has no default.
+func GetDurationPtr(key string) *time.Duration {
+	return getPtr(key, LookupDuration, GetDuration)
+}
+
+// Return a pointer to the valid value of the environment variable, or to the registered default,
+// or nil.
+func getPtr[T any](key string, lookup func(string) (T, bool), get func(string) T) *T {
+	if val, ok := lookup(key); ok {
+		return &val
+	}
+
+	if _, registered := Default(key); registered {
+		val := get(key)
+		return &val
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package dotenv
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Dereference the pointer returned by a Ptr getter, or return nil.
+func deref[T any](p *T) interface{} {
+	if p == nil {
+		return nil
+	}
+
+	return *p
+}
+
+func TestGetPtr(t *testing.T) {
+	tests := []struct {
+		name         string
+		get          func(key string) interface{}
+		valid        string
+		expected     interface{}
+		invalid      string
+		defaultValue interface{}
+	}{
+		{
+			name:         "GetStringPtr",
+			get:          func(key string) interface{} { return deref(GetStringPtr(key)) },
+			valid:        "",
+			expected:     "",
+			defaultValue: "default",
+		},
+		{
+			name:         "GetIntPtr",
+			get:          func(key string) interface{} { return deref(GetIntPtr(key)) },
+			valid:        "0",
+			expected:     0,
+			invalid:      "none",
+			defaultValue: 10,
+		},
+		{
+			name:         "GetInt64Ptr",
+			get:          func(key string) interface{} { return deref(GetInt64Ptr(key)) },
+			valid:        "9000000000",
+			expected:     int64(9000000000),
+			invalid:      "none",
+			defaultValue: int64(10),
+		},
+		{
+			name:         "GetFloat64Ptr",
+			get:          func(key string) interface{} { return deref(GetFloat64Ptr(key)) },
+			valid:        "0",
+			expected:     0.0,
+			invalid:      "none",
+			defaultValue: 0.5,
+		},
+		{
+			name:         "GetBoolPtr",
+			get:          func(key string) interface{} { return deref(GetBoolPtr(key)) },
+			valid:        "false",
+			expected:     false,
+			invalid:      "maybe",
+			defaultValue: true,
+		},
+		{
+			name:         "GetDurationPtr",
+			get:          func(key string) interface{} { return deref(GetDurationPtr(key)) },
+			valid:        "0s",
+			expected:     time.Duration(0),
+			invalid:      "soon",
+			defaultValue: time.Minute,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			key := "PTR_" + test.name
+			unsetenv(t, key)
+
+			if val := test.get(key); val != nil {
+				t.Errorf("expected nil when unset without a default; got %v", val)
+			}
+
+			t.Setenv(key, test.valid)
+			if val := test.get(key); !reflect.DeepEqual(val, test.expected) {
+				t.Errorf("%q: expected %v; got %v", test.valid, test.expected, val)
+			}
+
+			if test.invalid != "" {
+				t.Setenv(key, test.invalid)
+				if val := test.get(key); val != nil {
+					t.Errorf("%q: expected nil when invalid without a default; got %v", test.invalid, val)
+				}
+			}
+
+			Register(key, test.defaultValue, "A default for the Ptr getters")
+
+			unsetenv(t, key)
+			if val := test.get(key); !reflect.DeepEqual(val, test.defaultValue) {
+				t.Errorf("expected the default %v when unset; got %v", test.defaultValue, val)
+			}
+		})
+	}
+}
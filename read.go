@@ -0,0 +1,65 @@
+package dotenv
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// How long to wait before retrying a read, unless configured by the ReadRetryDelay option.  Each
+// retry waits a little longer.
+const defaultReadRetryDelay = 50 * time.Millisecond
+
+// Read and parse the file, retrying per the ReadRetries option if the file can't be parsed or
+// changes while it's being read, e.g. when it's regenerated by another tool.  The whole file is
+// read in one go, so the parser never sees a file that's partially written.
+func parseFile(filename string, env func(string) (string, bool)) ([]assignment, error) {
+	opts := currentOptions()
+
+	delay := opts.ReadRetryDelay
+	if delay <= 0 {
+		delay = defaultReadRetryDelay
+	}
+
+	for attempt := 0; ; attempt++ {
+		data, err := readFile(filename)
+
+		var assignments []assignment
+		if err == nil {
+			assignments, err = parseWith(osFiles, env, bytes.NewReader(data), filename)
+		}
+
+		if err == nil || attempt >= opts.ReadRetries || errors.Is(err, fs.ErrNotExist) {
+			return assignments, err
+		}
+
+		time.Sleep(delay * time.Duration(attempt+1))
+	}
+}
+
+// Read the entire file, failing if it changes while being read.
+func readFile(filename string) ([]byte, error) {
+	before, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if !after.ModTime().Equal(before.ModTime()) || after.Size() != before.Size() {
+		return nil, fmt.Errorf("%s changed while it was being read", filename)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,201 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// A file processed by a loader, remembered for Reload.  The name may be an envdir directory.
+type loadedFile struct {
+	name string
+	json bool
+	dir  bool
+}
+
+// How the most recent load was done, so Reload can do it again.
+type lastLoad struct {
+	files    []loadedFile
+	vars     map[string]string
+	override bool
+	existing map[string]bool
+	filter   func(key string) bool
+	prefix   string
+
+	// the environment before the files were loaded, for re-parsing them
+	before map[string]prior
+}
+
+var last *lastLoad
+var lastMutex sync.Mutex
+
+// Delta lists the keys changed by Reload.
+type Delta struct {
+	// Added are the keys in the files that weren't before.
+	Added []string
+
+	// Changed are the keys whose values changed in the files.
+	Changed []string
+
+	// Removed are the keys no longer in the files.  They're only unset if the ReloadUnsetsRemoved
+	// option is set.
+	Removed []string
+}
+
+// Empty is true if nothing changed.
+func (d Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// Reload re-reads the files read by the most recent load, such as Load or LoadFiles, and applies
+// any changes, e.g. when the application receives a SIGHUP.  Returns the keys that were added,
+// changed, or removed from the files, so the application can decide if it needs to restart.
+// Variables removed from the files are left alone, unless the ReloadUnsetsRemoved option is set.
+//
+// The files are loaded the same way they were originally, e.g. variables that were already set in
+// the environment before a LoadNoOverride are still left alone.  Thread-safe.
+func Reload() (Delta, error) {
+	lastMutex.Lock()
+	defer lastMutex.Unlock()
+
+	if last == nil {
+		return Delta{}, errors.New("nothing to reload; no files have been loaded")
+	}
+
+	probe, err := last.probe()
+	if err != nil {
+		return Delta{}, err
+	}
+
+	var delta Delta
+	var assignments []assignment
+
+	for key, value := range probe.vars {
+		old, ok := last.vars[key]
+		switch {
+		case !ok:
+			delta.Added = append(delta.Added, key)
+		case old != value:
+			delta.Changed = append(delta.Changed, key)
+		default:
+			continue
+		}
+
+		assignments = append(assignments, probe.origins[key])
+	}
+
+	for key := range last.vars {
+		if _, ok := probe.vars[key]; !ok {
+			delta.Removed = append(delta.Removed, key)
+
+			if currentOptions().ReloadUnsetsRemoved {
+				assignments = append(assignments, assignment{Key: key, Unset: true})
+			}
+		}
+	}
+
+	sort.Strings(delta.Added)
+	sort.Strings(delta.Changed)
+	sort.Strings(delta.Removed)
+
+	sort.Slice(assignments, func(i, j int) bool { return assignments[i].Key < assignments[j].Key })
+
+	if err := last.apply(assignments); err != nil {
+		return delta, err
+	}
+
+	last.vars = probe.vars
+
+	return delta, nil
+}
+
+// Re-parse the files, collecting the new values without changing anything.  Variables are looked
+// up as they were before the files were loaded, so "?=" and "+=" give the same results as the
+// original load rather than seeing the values it set.
+func (ll *lastLoad) probe() (*loader, error) {
+	probe := &loader{
+		override: ll.override,
+		existing: ll.existing,
+		filter:   ll.filter,
+		prefix:   ll.prefix,
+		set:      func(string, string) error { return nil },
+		env:      ll.lookupBefore,
+	}
+
+	var errs []error
+
+	for _, f := range ll.files {
+		var err error
+
+		switch {
+		case f.dir:
+			_, err = probe.processDir(f.name)
+		case f.json:
+			err = probe.processJSON(f.name)
+		default:
+			err = probe.process(f.name)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to load %s: %w", f.name, err))
+		}
+	}
+
+	return probe, errors.Join(errs...)
+}
+
+// Look up the variable as it was before the files were loaded.
+func (ll *lastLoad) lookupBefore(key string) (string, bool) {
+	if p, ok := ll.before[key]; ok {
+		return p.value, p.set
+	}
+
+	return osLookupEnv(key)
+}
+
+// Apply the changes found by re-parsing the files, first remembering the values of any variables
+// they hadn't set before, so the next re-parse doesn't see the changes either.
+func (ll *lastLoad) apply(assignments []assignment) error {
+	for _, a := range assignments {
+		if _, ok := ll.before[a.Key]; !ok {
+			p := prior{key: a.Key}
+			p.value, p.set = os.LookupEnv(a.Key)
+			ll.before[a.Key] = p
+		}
+	}
+
+	l := &loader{override: true}
+	return l.apply(assignments)
+}
+
+// Remember how the loader loaded its files, for Reload.
+func rememberLoad(l *loader) {
+	lastMutex.Lock()
+	defer lastMutex.Unlock()
+
+	vars := make(map[string]string, len(l.vars))
+	for key, value := range l.vars {
+		vars[key] = value
+	}
+
+	before := make(map[string]prior)
+	if l.layer != nil {
+		layerMutex.Lock()
+		for _, p := range l.layer.changes {
+			before[p.key] = p
+		}
+		layerMutex.Unlock()
+	}
+
+	last = &lastLoad{
+		files:    l.files,
+		vars:     vars,
+		override: l.override,
+		existing: l.existing,
+		filter:   l.filter,
+		prefix:   l.prefix,
+		before:   before,
+	}
+}
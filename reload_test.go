@@ -0,0 +1,88 @@
+package dotenv
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReloadConditionalAndAppend(t *testing.T) {
+	setOptions(t, Options{AppendSeparator: ",", ReloadUnsetsRemoved: true})
+	unsetenv(t, "LOG_LEVEL")
+	t.Setenv("FEATURES", "alpha")
+
+	filename := writeFile(t, ".env", "LOG_LEVEL ?= info\nFEATURES += beta\n")
+	if err := LoadFiles(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		delta, err := Reload()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !delta.Empty() {
+			t.Errorf("reload %d: expected no changes; got %+v", i, delta)
+		}
+
+		if val := os.Getenv("LOG_LEVEL"); val != "info" {
+			t.Errorf("reload %d: expected LOG_LEVEL to be info; got %q", i, val)
+		}
+
+		if val := os.Getenv("FEATURES"); val != "alpha,beta" {
+			t.Errorf("reload %d: expected FEATURES to be alpha,beta; got %q", i, val)
+		}
+	}
+
+	if err := os.WriteFile(filename, []byte("LOG_LEVEL ?= debug\nFEATURES += gamma\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	delta, err := Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"FEATURES", "LOG_LEVEL"}; !reflect.DeepEqual(delta.Changed, expected) {
+		t.Errorf("expected %v to change; got %+v", expected, delta)
+	}
+
+	if val := os.Getenv("LOG_LEVEL"); val != "debug" {
+		t.Errorf("expected LOG_LEVEL to be debug; got %q", val)
+	}
+
+	if val := os.Getenv("FEATURES"); val != "alpha,gamma" {
+		t.Errorf("expected FEATURES to be alpha,gamma; got %q", val)
+	}
+}
+
+func TestReloadAdded(t *testing.T) {
+	unsetenv(t, "RELOAD_ADDED", "RELOAD_OTHER")
+
+	filename := writeFile(t, ".env", "RELOAD_OTHER=1\n")
+	if err := LoadFiles(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filename, []byte("RELOAD_OTHER=1\nRELOAD_ADDED ?= yes\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		delta, err := Reload()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if i == 0 && !reflect.DeepEqual(delta.Added, []string{"RELOAD_ADDED"}) {
+			t.Errorf("expected RELOAD_ADDED to be added; got %+v", delta)
+		} else if i > 0 && !delta.Empty() {
+			t.Errorf("expected no changes; got %+v", delta)
+		}
+
+		if val := os.Getenv("RELOAD_ADDED"); val != "yes" {
+			t.Errorf("reload %d: expected RELOAD_ADDED to be yes; got %q", i, val)
+		}
+	}
+}
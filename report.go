@@ -0,0 +1,103 @@
+package dotenv
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Report summarizes what Load did, for logging when the application starts.  It may be marshaled
+// to JSON.
+type Report struct {
+	// Files considered by Load, in the order they were considered.
+	Files []FileReport `json:"files"`
+
+	// Set is the number of variables set or unset.
+	Set int `json:"set"`
+
+	// Skipped is the number of variables left alone because they were already set in the
+	// environment, i.e. with the NoOverride option.
+	Skipped int `json:"skipped"`
+}
+
+// FileReport summarizes what Load did with a single file.
+type FileReport struct {
+	Name     string        `json:"name"`
+	Found    bool          `json:"found"`
+	Loaded   bool          `json:"loaded"`
+	Error    string        `json:"error,omitempty"`
+	Set      int           `json:"set"`
+	Skipped  int           `json:"skipped"`
+	Duration time.Duration `json:"duration"`
+}
+
+// LoadReport loads the environment settings just like Load, but also returns a report of the files
+// considered and what was loaded from each, e.g. to see if a stray $HOME/.env file is interfering.
+func LoadReport() (Report, error) {
+	l := newLoader()
+	l.report = &Report{}
+
+	err := load(l)
+
+	return *l.report, err
+}
+
+// String describes the report for a person, e.g. in a log file.
+func (r Report) String() string {
+	var loaded int
+	for _, f := range r.Files {
+		if f.Loaded {
+			loaded++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "set %d variables (%d skipped) from %d of %d files", r.Set, r.Skipped, loaded, len(r.Files))
+
+	for _, f := range r.Files {
+		switch {
+		case !f.Found:
+			fmt.Fprintf(&b, "\n  %s: not found", f.Name)
+		case f.Error != "":
+			fmt.Fprintf(&b, "\n  %s: %s", f.Name, f.Error)
+		default:
+			fmt.Fprintf(&b, "\n  %s: %d set, %d skipped in %s", f.Name, f.Set, f.Skipped, f.Duration)
+		}
+	}
+
+	return b.String()
+}
+
+// Load the file with the process function if it exists, adding it to the report if there is one.
+func (l *loader) loadFile(filename string, process func(string) error) error {
+	found := exists(filename)
+	if l.report == nil {
+		if !found {
+			return nil
+		}
+
+		return process(filename)
+	}
+
+	f := FileReport{Name: filename, Found: found}
+	if !found {
+		l.report.Files = append(l.report.Files, f)
+		return nil
+	}
+
+	set, skipped := l.report.Set, l.report.Skipped
+	start := time.Now()
+
+	err := process(filename)
+
+	f.Duration = time.Since(start)
+	f.Set, f.Skipped = l.report.Set-set, l.report.Skipped-skipped
+	f.Loaded = err == nil
+	if err != nil {
+		f.Error = err.Error()
+	}
+
+	l.report.Files = append(l.report.Files, f)
+
+	return err
+}
@@ -0,0 +1,873 @@
+package dotenv
+
+import (
+	htmltemplate "html/template"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"text/template"
+	"time"
+)
+
+// Scoped reads the environment variables starting with a common prefix, such as "DB_", so a
+// package can reference its settings by their short names.  Create one with Scope.
+type Scoped struct {
+	prefix string
+}
+
+// Scope returns the settings for the environment variables starting with the prefix.  The Scoped
+// getters add the prefix to every key, so with db := Scope("DB_"), db.GetInt("PORT") returns the
+// value of DB_PORT.  Keys registered through the scope are prefixed too, so Help displays the full
+// names.  Pairs well with LoadWithPrefix.
+func Scope(prefix string) *Scoped {
+	return &Scoped{prefix: prefix}
+}
+
+// Prefix returns the prefix added to every key.
+func (s *Scoped) Prefix() string {
+	return s.prefix
+}
+
+// Scope returns a nested scope, adding the prefix to this scope's prefix, e.g. "DB_" and
+// "REPLICA_" reads DB_REPLICA_PORT.
+func (s *Scoped) Scope(prefix string) *Scoped {
+	return &Scoped{prefix: s.prefix + prefix}
+}
+
+// Register calls Register with the prefixed key.
+func (s *Scoped) Register(key string, defaultValue interface{}, description string) {
+	Register(s.prefix+key, defaultValue, description)
+}
+
+// RegisterSecret calls RegisterSecret with the prefixed key.
+func (s *Scoped) RegisterSecret(key string, defaultValue interface{}, description string) {
+	RegisterSecret(s.prefix+key, defaultValue, description)
+}
+
+// RegisterAlias calls RegisterAlias with the prefixed key and deprecated names.
+func (s *Scoped) RegisterAlias(key string, deprecated ...string) {
+	prefixed := make([]string, len(deprecated))
+	for i, alias := range deprecated {
+		prefixed[i] = s.prefix + alias
+	}
+
+	RegisterAlias(s.prefix+key, prefixed...)
+}
+
+// GetString calls GetString with the prefixed key.
+func (s *Scoped) GetString(key string) string {
+	return GetString(s.prefix + key)
+}
+
+// GetStringSlice calls GetStringSlice with the prefixed key.
+func (s *Scoped) GetStringSlice(key string) []string {
+	return GetStringSlice(s.prefix + key)
+}
+
+// GetStringSliceE calls GetStringSliceE with the prefixed key.
+func (s *Scoped) GetStringSliceE(key string) ([]string, error) {
+	return GetStringSliceE(s.prefix + key)
+}
+
+// GetStringSliceSep calls GetStringSliceSep with the prefixed key.
+func (s *Scoped) GetStringSliceSep(key, sep string) []string {
+	return GetStringSliceSep(s.prefix+key, sep)
+}
+
+// GetIntSlice calls GetIntSlice with the prefixed key.
+func (s *Scoped) GetIntSlice(key string) []int {
+	return GetIntSlice(s.prefix + key)
+}
+
+// GetInt64Slice calls GetInt64Slice with the prefixed key.
+func (s *Scoped) GetInt64Slice(key string) []int64 {
+	return GetInt64Slice(s.prefix + key)
+}
+
+// GetFloat64Slice calls GetFloat64Slice with the prefixed key.
+func (s *Scoped) GetFloat64Slice(key string) []float64 {
+	return GetFloat64Slice(s.prefix + key)
+}
+
+// GetDurationSlice calls GetDurationSlice with the prefixed key.
+func (s *Scoped) GetDurationSlice(key string) []time.Duration {
+	return GetDurationSlice(s.prefix + key)
+}
+
+// GetStringMap calls GetStringMap with the prefixed key.
+func (s *Scoped) GetStringMap(key string) map[string]string {
+	return GetStringMap(s.prefix + key)
+}
+
+// GetStringMapSep calls GetStringMapSep with the prefixed key.
+func (s *Scoped) GetStringMapSep(key, sep, kvSep string) map[string]string {
+	return GetStringMapSep(s.prefix+key, sep, kvSep)
+}
+
+// GetInt calls GetInt with the prefixed key.
+func (s *Scoped) GetInt(key string) int {
+	return GetInt(s.prefix + key)
+}
+
+// GetIntE calls GetIntE with the prefixed key.
+func (s *Scoped) GetIntE(key string) (int, error) {
+	return GetIntE(s.prefix + key)
+}
+
+// GetInt64 calls GetInt64 with the prefixed key.
+func (s *Scoped) GetInt64(key string) int64 {
+	return GetInt64(s.prefix + key)
+}
+
+// GetUint calls GetUint with the prefixed key.
+func (s *Scoped) GetUint(key string) uint {
+	return GetUint(s.prefix + key)
+}
+
+// GetUint64 calls GetUint64 with the prefixed key.
+func (s *Scoped) GetUint64(key string) uint64 {
+	return GetUint64(s.prefix + key)
+}
+
+// GetBytes calls GetBytes with the prefixed key.
+func (s *Scoped) GetBytes(key string) int64 {
+	return GetBytes(s.prefix + key)
+}
+
+// GetFloat64 calls GetFloat64 with the prefixed key.
+func (s *Scoped) GetFloat64(key string) float64 {
+	return GetFloat64(s.prefix + key)
+}
+
+// GetFloat64E calls GetFloat64E with the prefixed key.
+func (s *Scoped) GetFloat64E(key string) (float64, error) {
+	return GetFloat64E(s.prefix + key)
+}
+
+// GetBool calls GetBool with the prefixed key.
+func (s *Scoped) GetBool(key string) bool {
+	return GetBool(s.prefix + key)
+}
+
+// GetBoolE calls GetBoolE with the prefixed key.
+func (s *Scoped) GetBoolE(key string) (bool, error) {
+	return GetBoolE(s.prefix + key)
+}
+
+// GetDuration calls GetDuration with the prefixed key.
+func (s *Scoped) GetDuration(key string) time.Duration {
+	return GetDuration(s.prefix + key)
+}
+
+// GetDurationE calls GetDurationE with the prefixed key.
+func (s *Scoped) GetDurationE(key string) (time.Duration, error) {
+	return GetDurationE(s.prefix + key)
+}
+
+// GetDurationDefaultUnit calls GetDurationDefaultUnit with the prefixed key.
+func (s *Scoped) GetDurationDefaultUnit(key string, unit time.Duration) time.Duration {
+	return GetDurationDefaultUnit(s.prefix+key, unit)
+}
+
+// GetTime calls GetTime with the prefixed key.
+func (s *Scoped) GetTime(key string) time.Time {
+	return GetTime(s.prefix + key)
+}
+
+// GetTimeLayout calls GetTimeLayout with the prefixed key.
+func (s *Scoped) GetTimeLayout(key, layout string) time.Time {
+	return GetTimeLayout(s.prefix+key, layout)
+}
+
+// GetURL calls GetURL with the prefixed key.
+func (s *Scoped) GetURL(key string) *url.URL {
+	return GetURL(s.prefix + key)
+}
+
+// GetURLE calls GetURLE with the prefixed key.
+func (s *Scoped) GetURLE(key string) (*url.URL, error) {
+	return GetURLE(s.prefix + key)
+}
+
+// GetIP calls GetIP with the prefixed key.
+func (s *Scoped) GetIP(key string) net.IP {
+	return GetIP(s.prefix + key)
+}
+
+// GetCIDR calls GetCIDR with the prefixed key.
+func (s *Scoped) GetCIDR(key string) *net.IPNet {
+	return GetCIDR(s.prefix + key)
+}
+
+// GetCIDRSlice calls GetCIDRSlice with the prefixed key.
+func (s *Scoped) GetCIDRSlice(key string) []*net.IPNet {
+	return GetCIDRSlice(s.prefix + key)
+}
+
+// GetBase64 calls GetBase64 with the prefixed key.
+func (s *Scoped) GetBase64(key string) []byte {
+	return GetBase64(s.prefix + key)
+}
+
+// GetBase64E calls GetBase64E with the prefixed key.
+func (s *Scoped) GetBase64E(key string) ([]byte, error) {
+	return GetBase64E(s.prefix + key)
+}
+
+// GetHex calls GetHex with the prefixed key.
+func (s *Scoped) GetHex(key string) []byte {
+	return GetHex(s.prefix + key)
+}
+
+// GetHexE calls GetHexE with the prefixed key.
+func (s *Scoped) GetHexE(key string) ([]byte, error) {
+	return GetHexE(s.prefix + key)
+}
+
+// GetFileContents calls GetFileContents with the prefixed key.
+func (s *Scoped) GetFileContents(key string) ([]byte, error) {
+	return GetFileContents(s.prefix + key)
+}
+
+// GetRegexp calls GetRegexp with the prefixed key.
+func (s *Scoped) GetRegexp(key string) *regexp.Regexp {
+	return GetRegexp(s.prefix + key)
+}
+
+// GetRegexpE calls GetRegexpE with the prefixed key.
+func (s *Scoped) GetRegexpE(key string) (*regexp.Regexp, error) {
+	return GetRegexpE(s.prefix + key)
+}
+
+// GetJSON calls GetJSON with the prefixed key.
+func (s *Scoped) GetJSON(key string, target interface{}) error {
+	return GetJSON(s.prefix+key, target)
+}
+
+// MustGetJSON calls MustGetJSON with the prefixed key.
+func (s *Scoped) MustGetJSON(key string, target interface{}) {
+	MustGetJSON(s.prefix+key, target)
+}
+
+// LookupString calls LookupString with the prefixed key.
+func (s *Scoped) LookupString(key string) (string, bool) {
+	return LookupString(s.prefix + key)
+}
+
+// LookupStringSlice calls LookupStringSlice with the prefixed key.
+func (s *Scoped) LookupStringSlice(key string) ([]string, bool) {
+	return LookupStringSlice(s.prefix + key)
+}
+
+// LookupInt calls LookupInt with the prefixed key.
+func (s *Scoped) LookupInt(key string) (int, bool) {
+	return LookupInt(s.prefix + key)
+}
+
+// LookupInt64 calls LookupInt64 with the prefixed key.
+func (s *Scoped) LookupInt64(key string) (int64, bool) {
+	return LookupInt64(s.prefix + key)
+}
+
+// LookupUint calls LookupUint with the prefixed key.
+func (s *Scoped) LookupUint(key string) (uint, bool) {
+	return LookupUint(s.prefix + key)
+}
+
+// LookupUint64 calls LookupUint64 with the prefixed key.
+func (s *Scoped) LookupUint64(key string) (uint64, bool) {
+	return LookupUint64(s.prefix + key)
+}
+
+// LookupFloat64 calls LookupFloat64 with the prefixed key.
+func (s *Scoped) LookupFloat64(key string) (float64, bool) {
+	return LookupFloat64(s.prefix + key)
+}
+
+// LookupBool calls LookupBool with the prefixed key.
+func (s *Scoped) LookupBool(key string) (bool, bool) {
+	return LookupBool(s.prefix + key)
+}
+
+// LookupDuration calls LookupDuration with the prefixed key.
+func (s *Scoped) LookupDuration(key string) (time.Duration, bool) {
+	return LookupDuration(s.prefix + key)
+}
+
+// LookupBytes calls LookupBytes with the prefixed key.
+func (s *Scoped) LookupBytes(key string) (int64, bool) {
+	return LookupBytes(s.prefix + key)
+}
+
+// LookupTime calls LookupTime with the prefixed key.
+func (s *Scoped) LookupTime(key string) (time.Time, bool) {
+	return LookupTime(s.prefix + key)
+}
+
+// LookupTimeLayout calls LookupTimeLayout with the prefixed key.
+func (s *Scoped) LookupTimeLayout(key, layout string) (time.Time, bool) {
+	return LookupTimeLayout(s.prefix+key, layout)
+}
+
+// LookupIntSlice calls LookupIntSlice with the prefixed key.
+func (s *Scoped) LookupIntSlice(key string) ([]int, bool) {
+	return LookupIntSlice(s.prefix + key)
+}
+
+// LookupInt64Slice calls LookupInt64Slice with the prefixed key.
+func (s *Scoped) LookupInt64Slice(key string) ([]int64, bool) {
+	return LookupInt64Slice(s.prefix + key)
+}
+
+// LookupFloat64Slice calls LookupFloat64Slice with the prefixed key.
+func (s *Scoped) LookupFloat64Slice(key string) ([]float64, bool) {
+	return LookupFloat64Slice(s.prefix + key)
+}
+
+// LookupDurationSlice calls LookupDurationSlice with the prefixed key.
+func (s *Scoped) LookupDurationSlice(key string) ([]time.Duration, bool) {
+	return LookupDurationSlice(s.prefix + key)
+}
+
+// LookupStringMap calls LookupStringMap with the prefixed key.
+func (s *Scoped) LookupStringMap(key string) (map[string]string, bool) {
+	return LookupStringMap(s.prefix + key)
+}
+
+// LookupURL calls LookupURL with the prefixed key.
+func (s *Scoped) LookupURL(key string) (*url.URL, bool) {
+	return LookupURL(s.prefix + key)
+}
+
+// LookupIP calls LookupIP with the prefixed key.
+func (s *Scoped) LookupIP(key string) (net.IP, bool) {
+	return LookupIP(s.prefix + key)
+}
+
+// LookupCIDR calls LookupCIDR with the prefixed key.
+func (s *Scoped) LookupCIDR(key string) (*net.IPNet, bool) {
+	return LookupCIDR(s.prefix + key)
+}
+
+// LookupCIDRSlice calls LookupCIDRSlice with the prefixed key.
+func (s *Scoped) LookupCIDRSlice(key string) ([]*net.IPNet, bool) {
+	return LookupCIDRSlice(s.prefix + key)
+}
+
+// LookupBase64 calls LookupBase64 with the prefixed key.
+func (s *Scoped) LookupBase64(key string) ([]byte, bool) {
+	return LookupBase64(s.prefix + key)
+}
+
+// LookupHex calls LookupHex with the prefixed key.
+func (s *Scoped) LookupHex(key string) ([]byte, bool) {
+	return LookupHex(s.prefix + key)
+}
+
+// LookupRegexp calls LookupRegexp with the prefixed key.
+func (s *Scoped) LookupRegexp(key string) (*regexp.Regexp, bool) {
+	return LookupRegexp(s.prefix + key)
+}
+
+// MustGetString calls MustGetString with the prefixed key.
+func (s *Scoped) MustGetString(key string) string {
+	return MustGetString(s.prefix + key)
+}
+
+// MustGetStringSlice calls MustGetStringSlice with the prefixed key.
+func (s *Scoped) MustGetStringSlice(key string) []string {
+	return MustGetStringSlice(s.prefix + key)
+}
+
+// MustGetInt calls MustGetInt with the prefixed key.
+func (s *Scoped) MustGetInt(key string) int {
+	return MustGetInt(s.prefix + key)
+}
+
+// MustGetInt64 calls MustGetInt64 with the prefixed key.
+func (s *Scoped) MustGetInt64(key string) int64 {
+	return MustGetInt64(s.prefix + key)
+}
+
+// MustGetUint calls MustGetUint with the prefixed key.
+func (s *Scoped) MustGetUint(key string) uint {
+	return MustGetUint(s.prefix + key)
+}
+
+// MustGetUint64 calls MustGetUint64 with the prefixed key.
+func (s *Scoped) MustGetUint64(key string) uint64 {
+	return MustGetUint64(s.prefix + key)
+}
+
+// MustGetFloat64 calls MustGetFloat64 with the prefixed key.
+func (s *Scoped) MustGetFloat64(key string) float64 {
+	return MustGetFloat64(s.prefix + key)
+}
+
+// MustGetBool calls MustGetBool with the prefixed key.
+func (s *Scoped) MustGetBool(key string) bool {
+	return MustGetBool(s.prefix + key)
+}
+
+// MustGetDuration calls MustGetDuration with the prefixed key.
+func (s *Scoped) MustGetDuration(key string) time.Duration {
+	return MustGetDuration(s.prefix + key)
+}
+
+// MustGetTime calls MustGetTime with the prefixed key.
+func (s *Scoped) MustGetTime(key string) time.Time {
+	return MustGetTime(s.prefix + key)
+}
+
+// MustGetURL calls MustGetURL with the prefixed key.
+func (s *Scoped) MustGetURL(key string) *url.URL {
+	return MustGetURL(s.prefix + key)
+}
+
+// GetStringOr calls GetStringOr with the prefixed key.
+func (s *Scoped) GetStringOr(key string, fallback string) string {
+	return GetStringOr(s.prefix+key, fallback)
+}
+
+// GetStringSliceOr calls GetStringSliceOr with the prefixed key.
+func (s *Scoped) GetStringSliceOr(key string, fallback []string) []string {
+	return GetStringSliceOr(s.prefix+key, fallback)
+}
+
+// GetIntOr calls GetIntOr with the prefixed key.
+func (s *Scoped) GetIntOr(key string, fallback int) int {
+	return GetIntOr(s.prefix+key, fallback)
+}
+
+// GetInt64Or calls GetInt64Or with the prefixed key.
+func (s *Scoped) GetInt64Or(key string, fallback int64) int64 {
+	return GetInt64Or(s.prefix+key, fallback)
+}
+
+// GetUintOr calls GetUintOr with the prefixed key.
+func (s *Scoped) GetUintOr(key string, fallback uint) uint {
+	return GetUintOr(s.prefix+key, fallback)
+}
+
+// GetUint64Or calls GetUint64Or with the prefixed key.
+func (s *Scoped) GetUint64Or(key string, fallback uint64) uint64 {
+	return GetUint64Or(s.prefix+key, fallback)
+}
+
+// GetFloat64Or calls GetFloat64Or with the prefixed key.
+func (s *Scoped) GetFloat64Or(key string, fallback float64) float64 {
+	return GetFloat64Or(s.prefix+key, fallback)
+}
+
+// GetBoolOr calls GetBoolOr with the prefixed key.
+func (s *Scoped) GetBoolOr(key string, fallback bool) bool {
+	return GetBoolOr(s.prefix+key, fallback)
+}
+
+// GetDurationOr calls GetDurationOr with the prefixed key.
+func (s *Scoped) GetDurationOr(key string, fallback time.Duration) time.Duration {
+	return GetDurationOr(s.prefix+key, fallback)
+}
+
+// GetTimeOr calls GetTimeOr with the prefixed key.
+func (s *Scoped) GetTimeOr(key string, fallback time.Time) time.Time {
+	return GetTimeOr(s.prefix+key, fallback)
+}
+
+// GetURLOr calls GetURLOr with the prefixed key.
+func (s *Scoped) GetURLOr(key string, fallback *url.URL) *url.URL {
+	return GetURLOr(s.prefix+key, fallback)
+}
+
+// GetIPOr calls GetIPOr with the prefixed key.
+func (s *Scoped) GetIPOr(key string, fallback net.IP) net.IP {
+	return GetIPOr(s.prefix+key, fallback)
+}
+
+// GetCIDROr calls GetCIDROr with the prefixed key.
+func (s *Scoped) GetCIDROr(key string, fallback *net.IPNet) *net.IPNet {
+	return GetCIDROr(s.prefix+key, fallback)
+}
+
+// GetCIDRSliceOr calls GetCIDRSliceOr with the prefixed key.
+func (s *Scoped) GetCIDRSliceOr(key string, fallback []*net.IPNet) []*net.IPNet {
+	return GetCIDRSliceOr(s.prefix+key, fallback)
+}
+
+// GetBytesOr calls GetBytesOr with the prefixed key.
+func (s *Scoped) GetBytesOr(key string, fallback int64) int64 {
+	return GetBytesOr(s.prefix+key, fallback)
+}
+
+// GetIntSliceOr calls GetIntSliceOr with the prefixed key.
+func (s *Scoped) GetIntSliceOr(key string, fallback []int) []int {
+	return GetIntSliceOr(s.prefix+key, fallback)
+}
+
+// GetInt64SliceOr calls GetInt64SliceOr with the prefixed key.
+func (s *Scoped) GetInt64SliceOr(key string, fallback []int64) []int64 {
+	return GetInt64SliceOr(s.prefix+key, fallback)
+}
+
+// GetFloat64SliceOr calls GetFloat64SliceOr with the prefixed key.
+func (s *Scoped) GetFloat64SliceOr(key string, fallback []float64) []float64 {
+	return GetFloat64SliceOr(s.prefix+key, fallback)
+}
+
+// GetDurationSliceOr calls GetDurationSliceOr with the prefixed key.
+func (s *Scoped) GetDurationSliceOr(key string, fallback []time.Duration) []time.Duration {
+	return GetDurationSliceOr(s.prefix+key, fallback)
+}
+
+// GetStringMapOr calls GetStringMapOr with the prefixed key.
+func (s *Scoped) GetStringMapOr(key string, fallback map[string]string) map[string]string {
+	return GetStringMapOr(s.prefix+key, fallback)
+}
+
+// GetBase64Or calls GetBase64Or with the prefixed key.
+func (s *Scoped) GetBase64Or(key string, fallback []byte) []byte {
+	return GetBase64Or(s.prefix+key, fallback)
+}
+
+// GetHexOr calls GetHexOr with the prefixed key.
+func (s *Scoped) GetHexOr(key string, fallback []byte) []byte {
+	return GetHexOr(s.prefix+key, fallback)
+}
+
+// GetRegexpOr calls GetRegexpOr with the prefixed key.
+func (s *Scoped) GetRegexpOr(key string, fallback *regexp.Regexp) *regexp.Regexp {
+	return GetRegexpOr(s.prefix+key, fallback)
+}
+
+// RegisterEnum calls RegisterEnum with the prefixed key.
+func (s *Scoped) RegisterEnum(key, defaultValue, description string, allowed ...string) {
+	RegisterEnum(s.prefix+key, defaultValue, description, allowed...)
+}
+
+// GetEnum calls GetEnum with the prefixed key.
+func (s *Scoped) GetEnum(key string, allowed ...string) string {
+	return GetEnum(s.prefix+key, allowed...)
+}
+
+// GetEnumE calls GetEnumE with the prefixed key.
+func (s *Scoped) GetEnumE(key string, allowed ...string) (string, error) {
+	return GetEnumE(s.prefix+key, allowed...)
+}
+
+// LookupEnum calls LookupEnum with the prefixed key.
+func (s *Scoped) LookupEnum(key string, allowed ...string) (string, bool) {
+	return LookupEnum(s.prefix+key, allowed...)
+}
+
+// GetEnumOr calls GetEnumOr with the prefixed key.
+func (s *Scoped) GetEnumOr(key, fallback string, allowed ...string) string {
+	return GetEnumOr(s.prefix+key, fallback, allowed...)
+}
+
+// GetLogLevel calls GetLogLevel with the prefixed key.
+func (s *Scoped) GetLogLevel(key string) slog.Level {
+	return GetLogLevel(s.prefix + key)
+}
+
+// GetLogLevelE calls GetLogLevelE with the prefixed key.
+func (s *Scoped) GetLogLevelE(key string) (slog.Level, error) {
+	return GetLogLevelE(s.prefix + key)
+}
+
+// LookupLogLevel calls LookupLogLevel with the prefixed key.
+func (s *Scoped) LookupLogLevel(key string) (slog.Level, bool) {
+	return LookupLogLevel(s.prefix + key)
+}
+
+// GetLogLevelOr calls GetLogLevelOr with the prefixed key.
+func (s *Scoped) GetLogLevelOr(key string, fallback slog.Level) slog.Level {
+	return GetLogLevelOr(s.prefix+key, fallback)
+}
+
+// GetHostPort calls GetHostPort with the prefixed key.
+func (s *Scoped) GetHostPort(key string) (host string, port int, ok bool) {
+	return GetHostPort(s.prefix + key)
+}
+
+// GetHostPortSlice calls GetHostPortSlice with the prefixed key.
+func (s *Scoped) GetHostPortSlice(key string) []string {
+	return GetHostPortSlice(s.prefix + key)
+}
+
+// GetExistingPath calls GetExistingPath with the prefixed key.
+func (s *Scoped) GetExistingPath(key string) (string, error) {
+	return GetExistingPath(s.prefix + key)
+}
+
+// GetDir calls GetDir with the prefixed key.
+func (s *Scoped) GetDir(key string) (string, error) {
+	return GetDir(s.prefix + key)
+}
+
+// GetTimeZone calls GetTimeZone with the prefixed key.
+func (s *Scoped) GetTimeZone(key string) *time.Location {
+	return GetTimeZone(s.prefix + key)
+}
+
+// GetTimeZoneE calls GetTimeZoneE with the prefixed key.
+func (s *Scoped) GetTimeZoneE(key string) (*time.Location, error) {
+	return GetTimeZoneE(s.prefix + key)
+}
+
+// LookupTimeZone calls LookupTimeZone with the prefixed key.
+func (s *Scoped) LookupTimeZone(key string) (*time.Location, bool) {
+	return LookupTimeZone(s.prefix + key)
+}
+
+// GetTimeZoneOr calls GetTimeZoneOr with the prefixed key.
+func (s *Scoped) GetTimeZoneOr(key string, fallback *time.Location) *time.Location {
+	return GetTimeZoneOr(s.prefix+key, fallback)
+}
+
+// Has calls Has with the prefixed key.
+func (s *Scoped) Has(key string) bool {
+	return Has(s.prefix + key)
+}
+
+// GetPort calls GetPort with the prefixed key.
+func (s *Scoped) GetPort(key string) int {
+	return GetPort(s.prefix + key)
+}
+
+// GetPortE calls GetPortE with the prefixed key.
+func (s *Scoped) GetPortE(key string) (int, error) {
+	return GetPortE(s.prefix + key)
+}
+
+// LookupPort calls LookupPort with the prefixed key.
+func (s *Scoped) LookupPort(key string) (int, bool) {
+	return LookupPort(s.prefix + key)
+}
+
+// GetPortOr calls GetPortOr with the prefixed key.
+func (s *Scoped) GetPortOr(key string, fallback int) int {
+	return GetPortOr(s.prefix+key, fallback)
+}
+
+// GetCSV calls GetCSV with the prefixed key.
+func (s *Scoped) GetCSV(key string) []string {
+	return GetCSV(s.prefix + key)
+}
+
+// LookupCSV calls LookupCSV with the prefixed key.
+func (s *Scoped) LookupCSV(key string) ([]string, bool) {
+	return LookupCSV(s.prefix + key)
+}
+
+// GetCSVOr calls GetCSVOr with the prefixed key.
+func (s *Scoped) GetCSVOr(key string, fallback []string) []string {
+	return GetCSVOr(s.prefix+key, fallback)
+}
+
+// GetStringSet calls GetStringSet with the prefixed key.
+func (s *Scoped) GetStringSet(key string) map[string]struct{} {
+	return GetStringSet(s.prefix + key)
+}
+
+// GetStringSetFold calls GetStringSetFold with the prefixed key.
+func (s *Scoped) GetStringSetFold(key string) map[string]struct{} {
+	return GetStringSetFold(s.prefix + key)
+}
+
+// InSet calls InSet with the prefixed key.
+func (s *Scoped) InSet(key, member string) bool {
+	return InSet(s.prefix+key, member)
+}
+
+// InSetFold calls InSetFold with the prefixed key.
+func (s *Scoped) InSetFold(key, member string) bool {
+	return InSetFold(s.prefix+key, member)
+}
+
+// GetIntRange calls GetIntRange with the prefixed key.
+func (s *Scoped) GetIntRange(key string) (lo, hi int, ok bool) {
+	return GetIntRange(s.prefix + key)
+}
+
+// GetPercent calls GetPercent with the prefixed key.
+func (s *Scoped) GetPercent(key string) float64 {
+	return GetPercent(s.prefix + key)
+}
+
+// GetPercentE calls GetPercentE with the prefixed key.
+func (s *Scoped) GetPercentE(key string) (float64, error) {
+	return GetPercentE(s.prefix + key)
+}
+
+// LookupPercent calls LookupPercent with the prefixed key.
+func (s *Scoped) LookupPercent(key string) (float64, bool) {
+	return LookupPercent(s.prefix + key)
+}
+
+// GetPercentOr calls GetPercentOr with the prefixed key.
+func (s *Scoped) GetPercentOr(key string, fallback float64) float64 {
+	return GetPercentOr(s.prefix+key, fallback)
+}
+
+// GetStringPtr calls GetStringPtr with the prefixed key.
+func (s *Scoped) GetStringPtr(key string) *string {
+	return GetStringPtr(s.prefix + key)
+}
+
+// GetIntPtr calls GetIntPtr with the prefixed key.
+func (s *Scoped) GetIntPtr(key string) *int {
+	return GetIntPtr(s.prefix + key)
+}
+
+// GetInt64Ptr calls GetInt64Ptr with the prefixed key.
+func (s *Scoped) GetInt64Ptr(key string) *int64 {
+	return GetInt64Ptr(s.prefix + key)
+}
+
+// GetFloat64Ptr calls GetFloat64Ptr with the prefixed key.
+func (s *Scoped) GetFloat64Ptr(key string) *float64 {
+	return GetFloat64Ptr(s.prefix + key)
+}
+
+// GetBoolPtr calls GetBoolPtr with the prefixed key.
+func (s *Scoped) GetBoolPtr(key string) *bool {
+	return GetBoolPtr(s.prefix + key)
+}
+
+// GetDurationPtr calls GetDurationPtr with the prefixed key.
+func (s *Scoped) GetDurationPtr(key string) *time.Duration {
+	return GetDurationPtr(s.prefix + key)
+}
+
+// GetStringExpanded calls GetStringExpanded with the prefixed key.
+func (s *Scoped) GetStringExpanded(key string) string {
+	return GetStringExpanded(s.prefix + key)
+}
+
+// GetStringExpandedE calls GetStringExpandedE with the prefixed key.
+func (s *Scoped) GetStringExpandedE(key string) (string, error) {
+	return GetStringExpandedE(s.prefix + key)
+}
+
+// GetBigInt calls GetBigInt with the prefixed key.
+func (s *Scoped) GetBigInt(key string) *big.Int {
+	return GetBigInt(s.prefix + key)
+}
+
+// GetRat calls GetRat with the prefixed key.
+func (s *Scoped) GetRat(key string) *big.Rat {
+	return GetRat(s.prefix + key)
+}
+
+// LookupBigInt calls LookupBigInt with the prefixed key.
+func (s *Scoped) LookupBigInt(key string) (*big.Int, bool) {
+	return LookupBigInt(s.prefix + key)
+}
+
+// LookupRat calls LookupRat with the prefixed key.
+func (s *Scoped) LookupRat(key string) (*big.Rat, bool) {
+	return LookupRat(s.prefix + key)
+}
+
+// RegisterURLSlice calls RegisterURLSlice with the prefixed key.
+func (s *Scoped) RegisterURLSlice(key string, defaultValue []string, description string) {
+	RegisterURLSlice(s.prefix+key, defaultValue, description)
+}
+
+// RegisterIPSlice calls RegisterIPSlice with the prefixed key.
+func (s *Scoped) RegisterIPSlice(key string, defaultValue []string, description string) {
+	RegisterIPSlice(s.prefix+key, defaultValue, description)
+}
+
+// GetURLSlice calls GetURLSlice with the prefixed key.
+func (s *Scoped) GetURLSlice(key string) []*url.URL {
+	return GetURLSlice(s.prefix + key)
+}
+
+// GetURLSliceE calls GetURLSliceE with the prefixed key.
+func (s *Scoped) GetURLSliceE(key string) ([]*url.URL, error) {
+	return GetURLSliceE(s.prefix + key)
+}
+
+// GetIPSlice calls GetIPSlice with the prefixed key.
+func (s *Scoped) GetIPSlice(key string) []net.IP {
+	return GetIPSlice(s.prefix + key)
+}
+
+// GetIPSliceE calls GetIPSliceE with the prefixed key.
+func (s *Scoped) GetIPSliceE(key string) ([]net.IP, error) {
+	return GetIPSliceE(s.prefix + key)
+}
+
+// RegisterVersion calls RegisterVersion with the prefixed key.
+func (s *Scoped) RegisterVersion(key, defaultValue, description string) {
+	RegisterVersion(s.prefix+key, defaultValue, description)
+}
+
+// GetSemVer calls GetSemVer with the prefixed key.
+func (s *Scoped) GetSemVer(key string) Version {
+	return GetSemVer(s.prefix + key)
+}
+
+// GetSemVerE calls GetSemVerE with the prefixed key.
+func (s *Scoped) GetSemVerE(key string) (Version, error) {
+	return GetSemVerE(s.prefix + key)
+}
+
+// LookupSemVer calls LookupSemVer with the prefixed key.
+func (s *Scoped) LookupSemVer(key string) (Version, bool) {
+	return LookupSemVer(s.prefix + key)
+}
+
+// GetBasicAuth calls GetBasicAuth with the prefixed key.
+func (s *Scoped) GetBasicAuth(key string) (user, pass string, ok bool) {
+	return GetBasicAuth(s.prefix + key)
+}
+
+// GetHeaderMap calls GetHeaderMap with the prefixed key.
+func (s *Scoped) GetHeaderMap(key string) http.Header {
+	return GetHeaderMap(s.prefix + key)
+}
+
+// GetDSN calls GetDSN with the prefixed key.
+func (s *Scoped) GetDSN(key string) (DSN, bool) {
+	return GetDSN(s.prefix + key)
+}
+
+// GetDSNE calls GetDSNE with the prefixed key.
+func (s *Scoped) GetDSNE(key string) (DSN, error) {
+	return GetDSNE(s.prefix + key)
+}
+
+// RegisterCron calls RegisterCron with the prefixed key.
+func (s *Scoped) RegisterCron(key, defaultValue, description string) {
+	RegisterCron(s.prefix+key, defaultValue, description)
+}
+
+// GetCron calls GetCron with the prefixed key.
+func (s *Scoped) GetCron(key string) (string, error) {
+	return GetCron(s.prefix + key)
+}
+
+// GetTemplate calls GetTemplate with the prefixed key.
+func (s *Scoped) GetTemplate(key string) (*template.Template, error) {
+	return GetTemplate(s.prefix + key)
+}
+
+// GetHTMLTemplate calls GetHTMLTemplate with the prefixed key.
+func (s *Scoped) GetHTMLTemplate(key string) (*htmltemplate.Template, error) {
+	return GetHTMLTemplate(s.prefix + key)
+}
+
+// RegisterE calls RegisterE with the prefixed key.
+func (s *Scoped) RegisterE(key string, defaultValue interface{}, description string) error {
+	return RegisterE(s.prefix+key, defaultValue, description)
+}
+
+// RegisterValidated calls RegisterValidated with the prefixed key.
+func (s *Scoped) RegisterValidated(key string, defaultValue interface{}, description string, validate func(value string) error) {
+	RegisterValidated(s.prefix+key, defaultValue, description, validate)
+}
@@ -0,0 +1,84 @@
+package dotenv
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestScope(t *testing.T) {
+	db := Scope("SCOPE_DB_")
+	unsetenv(t, "SCOPE_DB_PORT", "SCOPE_DB_TIMEOUT")
+	db.Register("PORT", 5432, "The database port")
+
+	if db.Prefix() != "SCOPE_DB_" {
+		t.Errorf("expected the prefix SCOPE_DB_; got %q", db.Prefix())
+	}
+
+	if _, ok := Default("SCOPE_DB_PORT"); !ok {
+		t.Error("expected the default to be registered with the prefixed key")
+	}
+
+	if port := db.GetInt("PORT"); port != 5432 {
+		t.Errorf("expected the default port when unset; got %d", port)
+	}
+
+	if !db.Has("PORT") || db.Has("TIMEOUT") {
+		t.Error("expected Has to report the registered PORT, but not TIMEOUT")
+	}
+
+	t.Setenv("SCOPE_DB_PORT", "6543")
+	if port := db.GetInt("PORT"); port != 6543 {
+		t.Errorf("expected SCOPE_DB_PORT; got %d", port)
+	}
+
+	if port, ok := db.LookupInt("PORT"); !ok || port != 6543 {
+		t.Errorf("expected LookupInt to find SCOPE_DB_PORT; got %d, %v", port, ok)
+	}
+
+	t.Setenv("SCOPE_DB_PORT", "none")
+	if port := db.GetInt("PORT"); port != 5432 {
+		t.Errorf("expected the default port when invalid; got %d", port)
+	}
+
+	_, err := db.GetIntE("PORT")
+
+	var perr *ParseValueError
+	if !errors.As(err, &perr) || perr.Key != "SCOPE_DB_PORT" {
+		t.Errorf("expected the error to name the prefixed key; got %v", err)
+	}
+
+	if timeout := db.GetDuration("TIMEOUT"); timeout != 0 {
+		t.Errorf("expected no timeout when unset; got %s", timeout)
+	}
+
+	t.Setenv("SCOPE_DB_TIMEOUT", "5s")
+	if timeout := db.GetDuration("TIMEOUT"); timeout != 5*time.Second {
+		t.Errorf("expected SCOPE_DB_TIMEOUT; got %s", timeout)
+	}
+}
+
+func TestScopeNested(t *testing.T) {
+	replica := Scope("SCOPE_DB_").Scope("REPLICA_")
+	t.Setenv("SCOPE_DB_REPLICA_HOSTS", "a,b")
+
+	if replica.Prefix() != "SCOPE_DB_REPLICA_" {
+		t.Errorf("expected the prefixes to be joined; got %q", replica.Prefix())
+	}
+
+	if hosts := replica.GetStringSlice("HOSTS"); !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("expected SCOPE_DB_REPLICA_HOSTS; got %v", hosts)
+	}
+}
+
+func TestScopeAlias(t *testing.T) {
+	cache := Scope("SCOPE_CACHE_")
+	unsetenv(t, "SCOPE_CACHE_ADDR")
+	t.Setenv("SCOPE_CACHE_REDIS", "localhost:6379")
+	cache.RegisterAlias("ADDR", "REDIS")
+
+	if addr := cache.GetString("ADDR"); addr != "localhost:6379" {
+		t.Errorf("expected the prefixed alias to be used; got %q", addr)
+	}
+}
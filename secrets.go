@@ -0,0 +1,72 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The suffix of a variable naming the file holding the value of another variable, e.g.
+// DB_PASSWORD_FILE=/run/secrets/db_password.
+const fileSuffix = "_FILE"
+
+// ResolveFileSecrets follows the Docker and Kubernetes convention of passing secrets in files.  For
+// every environment variable ending in _FILE, such as DB_PASSWORD_FILE=/run/secrets/db_password,
+// sets the variable without the suffix, DB_PASSWORD, to the trimmed contents of the file, unless
+// it's already set.  Returns an error naming the key and path of each file that couldn't be read.
+//
+// Set the FileSecrets option to do this automatically after loading.
+func ResolveFileSecrets() error {
+	return newLoader().resolveFileSecrets()
+}
+
+// Set the variable for each _FILE variable to the contents of the file, unless it's already set.
+func (l *loader) resolveFileSecrets() error {
+	keys := make(map[string]bool)
+	for _, env := range os.Environ() {
+		keys[strings.SplitN(env, "=", 2)[0]] = true
+	}
+
+	for key := range l.vars {
+		keys[key] = true
+	}
+
+	names := make([]string, 0, len(keys))
+	for key := range keys {
+		if strings.HasSuffix(key, fileSuffix) && len(key) > len(fileSuffix) {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+
+	var assignments []assignment
+	var errs []error
+
+	for _, name := range names {
+		filename, ok := l.lookup(name)
+		if !ok || filename == "" {
+			continue
+		}
+
+		key := strings.TrimSuffix(name, fileSuffix)
+		if _, set := l.lookup(key); set {
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to read %s for %s: %w", filename, name, err))
+			continue
+		}
+
+		assignments = append(assignments, assignment{Key: key, Value: strings.TrimSpace(string(data)), File: filename})
+	}
+
+	if err := l.apply(assignments); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,163 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Has returns true if the environment variable is set, even to a blank string, or is registered
+// with a default.
+func Has(key string) bool {
+	if _, set := lookupEnv(key); set {
+		return true
+	}
+
+	_, registered := Default(key)
+	return registered
+}
+
+// Keys returns the keys of the registered environment variables, sorted.
+func Keys() []string {
+	regMutex.RLock()
+	defer regMutex.RUnlock()
+
+	keys := make([]string, 0, len(registered))
+	for key := range registered {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// AllSettings returns the current value of every registered environment variable, as returned by
+// the getter for its registered type, e.g. an int for a key registered with an int default.  Use
+// it to display the effective configuration.  The values of secrets are replaced by "(redacted)".
+// Binary values are decoded from base64.
+func AllSettings() map[string]interface{} {
+	settings := make(map[string]interface{})
+
+	for _, key := range Keys() {
+		d, ok := Default(key)
+		if !ok {
+			continue
+		}
+
+		if d.Secret {
+			settings[key] = redacted
+			continue
+		}
+
+		settings[key] = currentValue(d)
+	}
+
+	return settings
+}
+
+// Get the value of the registered environment variable with the getter for its type.
+func currentValue(d descriptor) interface{} {
+	key := d.Var
+
+	switch d.DataType {
+	case StringType:
+		if len(d.Allowed) > 0 {
+			return GetEnum(key)
+		}
+
+		return GetString(key)
+	case StringSliceType:
+		return GetStringSlice(key)
+	case IntType:
+		return GetInt(key)
+	case Int64Type:
+		return GetInt64(key)
+	case Float64Type:
+		return GetFloat64(key)
+	case BoolType:
+		return GetBool(key)
+	case DurationType:
+		return GetDuration(key)
+	case UintType:
+		return GetUint(key)
+	case Uint64Type:
+		return GetUint64(key)
+	case TimeType:
+		return GetTime(key)
+	case URLType:
+		return GetURL(key)
+	case IPType:
+		return GetIP(key)
+	case CIDRType:
+		return GetCIDR(key)
+	case CIDRSliceType:
+		return GetCIDRSlice(key)
+	case BytesType:
+		return ByteSize(GetBytes(key))
+	case IntSliceType:
+		return GetIntSlice(key)
+	case Int64SliceType:
+		return GetInt64Slice(key)
+	case Float64SliceType:
+		return GetFloat64Slice(key)
+	case DurationSliceType:
+		return GetDurationSlice(key)
+	case MapType:
+		return GetStringMap(key)
+	case BinaryType:
+		return GetBase64(key)
+	case RegexpType:
+		return GetRegexp(key)
+	case LogLevelType:
+		return GetLogLevel(key)
+	case TimeZoneType:
+		return GetTimeZone(key)
+	case PortType:
+		return GetPort(key)
+	case StringSetType:
+		return GetStringSet(key)
+	case IntRangeType:
+		lo, hi, _ := GetIntRange(key)
+		return [2]int{lo, hi}
+	case BigIntType:
+		return GetBigInt(key)
+	case RatType:
+		return GetRat(key)
+	case URLSliceType:
+		return GetURLSlice(key)
+	case IPSliceType:
+		return GetIPSlice(key)
+	case VersionType:
+		return GetSemVer(key)
+	case HeaderType:
+		return GetHeaderMap(key)
+	}
+
+	return d.DefaultValue
+}
+
+// Validate runs the validator for each variable registered with RegisterValidated against its
+// effective value, from the environment or, if it isn't set, the default.  Call it at startup to
+// fail fast on a misconfiguration.  Returns an error listing each variable that failed, by key,
+// with the validator's message.  The values aren't included, in case they're secrets.
+func Validate() error {
+	var errs []error
+
+	for _, key := range Keys() {
+		d, ok := Default(key)
+		if !ok || d.Validator == nil {
+			continue
+		}
+
+		val, set := lookupEnv(key)
+		if !set {
+			val = formatDefault(d.DefaultValue)
+		}
+
+		if err := d.Validator(val); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,71 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// EnvSnapshot captures the complete environment at a point in time, so it may be restored later.
+type EnvSnapshot struct {
+	vars map[string]string
+}
+
+// Snapshot captures the current environment.  Call Restore on the snapshot to reset the
+// environment, for example after a test loads a fixture .env file.
+func Snapshot() *EnvSnapshot {
+	snapshot := &EnvSnapshot{vars: make(map[string]string)}
+
+	for _, env := range os.Environ() {
+		parts := strings.SplitN(env, "=", 2)
+		if len(parts) == 2 {
+			snapshot.vars[parts[0]] = parts[1]
+		}
+	}
+
+	return snapshot
+}
+
+// Restore resets the environment to exactly what it was when the snapshot was taken.  Variables
+// set since are unset, and changed variables get their original values back.
+func (s *EnvSnapshot) Restore() error {
+	for _, env := range os.Environ() {
+		key := strings.SplitN(env, "=", 2)[0]
+		if _, ok := s.vars[key]; !ok {
+			if err := os.Unsetenv(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	for key, value := range s.vars {
+		if current, set := os.LookupEnv(key); set && current == value {
+			continue
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TestLoad loads the files for the duration of a test, like LoadFiles, then restores the
+// environment when the test and its subtests complete.  Fails the test immediately if the files
+// can't be loaded.  Because the environment is shared by the entire process, don't use this in
+// parallel tests.
+func TestLoad(t testing.TB, filenames ...string) {
+	t.Helper()
+
+	snapshot := Snapshot()
+	t.Cleanup(func() {
+		if err := snapshot.Restore(); err != nil {
+			t.Errorf("unable to restore the environment: %s", err)
+		}
+	})
+
+	if err := LoadFiles(filenames...); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,55 @@
+package dotenv
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Setenv("SNAPSHOT_MODIFIED", "original")
+	t.Setenv("SNAPSHOT_REMOVED", "original")
+	t.Setenv("SNAPSHOT_EMPTY", "")
+	unsetenv(t, "SNAPSHOT_ADDED")
+
+	snapshot := Snapshot()
+
+	os.Setenv("SNAPSHOT_MODIFIED", "changed")
+	os.Unsetenv("SNAPSHOT_REMOVED")
+	os.Unsetenv("SNAPSHOT_EMPTY")
+	os.Setenv("SNAPSHOT_ADDED", "added")
+
+	if err := snapshot.Restore(); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"SNAPSHOT_MODIFIED", "SNAPSHOT_REMOVED"} {
+		if val, set := os.LookupEnv(key); !set || val != "original" {
+			t.Errorf("expected %s to be restored to original; got %q, %v", key, val, set)
+		}
+	}
+
+	if val, set := os.LookupEnv("SNAPSHOT_EMPTY"); !set || val != "" {
+		t.Errorf("expected SNAPSHOT_EMPTY to be restored as set and empty; got %q, %v", val, set)
+	}
+
+	if val, set := os.LookupEnv("SNAPSHOT_ADDED"); set {
+		t.Errorf("expected SNAPSHOT_ADDED to be unset; got %q", val)
+	}
+}
+
+func TestTestLoad(t *testing.T) {
+	unsetenv(t, "SNAPSHOT_FIXTURE")
+	filename := writeFile(t, ".env", "SNAPSHOT_FIXTURE=loaded\n")
+
+	t.Run("fixture", func(t *testing.T) {
+		TestLoad(t, filename)
+
+		if val := os.Getenv("SNAPSHOT_FIXTURE"); val != "loaded" {
+			t.Errorf("expected the fixture to be loaded; got %q", val)
+		}
+	})
+
+	if val, set := os.LookupEnv("SNAPSHOT_FIXTURE"); set {
+		t.Errorf("expected the fixture to be unloaded after the test; got %q", val)
+	}
+}
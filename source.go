@@ -0,0 +1,70 @@
+package dotenv
+
+import (
+	"fmt"
+	"sync"
+)
+
+// LoadedVar records an environment variable assigned by one of the Load functions.
+type LoadedVar struct {
+	Key       string
+	Value     string
+	File      string
+	Line      int
+	Overwrote bool
+}
+
+// Source describes where the variable was loaded from, e.g. ".env:12".
+func (v LoadedVar) Source() string {
+	if v.Line == 0 {
+		return v.File
+	}
+
+	return fmt.Sprintf("%s:%d", v.File, v.Line)
+}
+
+// Track the variables loaded and from where.
+var loaded []LoadedVar
+var sources = make(map[string]int)
+var loadedMutex sync.RWMutex
+
+// Loaded returns every variable assigned by the Load functions, in the order they were assigned.
+// A variable assigned by more than one file appears more than once.  Thread-safe.
+func Loaded() []LoadedVar {
+	loadedMutex.RLock()
+	defer loadedMutex.RUnlock()
+
+	return append([]LoadedVar(nil), loaded...)
+}
+
+// Source returns where the current value of the environment variable was loaded from, e.g.
+// ".env:12".  Returns false if the variable wasn't set by one of the Load functions, for example
+// if it was set in the environment.  Thread-safe.
+func Source(key string) (string, bool) {
+	loadedMutex.RLock()
+	defer loadedMutex.RUnlock()
+
+	idx, ok := sources[key]
+	if !ok {
+		return "", false
+	}
+
+	return loaded[idx].Source(), true
+}
+
+// Record the variable as loaded.
+func track(v LoadedVar) {
+	loadedMutex.Lock()
+	defer loadedMutex.Unlock()
+
+	loaded = append(loaded, v)
+	sources[v.Key] = len(loaded) - 1
+}
+
+// Forget where the variable came from, i.e. when it's unset.
+func untrack(key string) {
+	loadedMutex.Lock()
+	defer loadedMutex.Unlock()
+
+	delete(sources, key)
+}
@@ -0,0 +1,92 @@
+package dotenv
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"sync"
+	"text/template"
+)
+
+// Compiled templates, by key, so repeated calls don't parse them again.  Each entry keeps the value
+// it was compiled from, and is replaced when the value changes.
+var (
+	templates     = make(map[string]cachedTemplate)
+	htmlTemplates = make(map[string]cachedHTMLTemplate)
+	templateMutex sync.Mutex
+)
+
+// A compiled text/template and the value it was parsed from.
+type cachedTemplate struct {
+	src  string
+	tmpl *template.Template
+}
+
+// A compiled html/template and the value it was parsed from.
+type cachedHTMLTemplate struct {
+	src  string
+	tmpl *htmltemplate.Template
+}
+
+// GetTemplate returns the environment variable parsed as a text/template, named for the key, such
+// as GREETING_TEMPLATE="Hello {{.Name}}, your order {{.ID}} shipped".  If the environment variable
+// doesn't exist, parses the default value if present, otherwise returns ErrNotSet.  Returns an
+// error naming the key and the line if the template is invalid.  The compiled template is cached
+// until the value changes, and shared by every call, so don't modify it, e.g. with Funcs.
+func GetTemplate(key string) (*template.Template, error) {
+	val, err := templateSource(key)
+	if err != nil {
+		return nil, err
+	}
+
+	templateMutex.Lock()
+	defer templateMutex.Unlock()
+
+	if cached, ok := templates[key]; ok && cached.src == val {
+		return cached.tmpl, nil
+	}
+
+	tmpl, err := template.New(key).Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template in %s: %w", key, err)
+	}
+
+	templates[key] = cachedTemplate{src: val, tmpl: tmpl}
+	return tmpl, nil
+}
+
+// GetHTMLTemplate returns the environment variable parsed as an html/template, like GetTemplate,
+// for output that must be safe to embed in HTML.
+func GetHTMLTemplate(key string) (*htmltemplate.Template, error) {
+	val, err := templateSource(key)
+	if err != nil {
+		return nil, err
+	}
+
+	templateMutex.Lock()
+	defer templateMutex.Unlock()
+
+	if cached, ok := htmlTemplates[key]; ok && cached.src == val {
+		return cached.tmpl, nil
+	}
+
+	tmpl, err := htmltemplate.New(key).Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template in %s: %w", key, err)
+	}
+
+	htmlTemplates[key] = cachedHTMLTemplate{src: val, tmpl: tmpl}
+	return tmpl, nil
+}
+
+// Get the template from the environment variable or the default.
+func templateSource(key string) (string, error) {
+	if val, set := lookupEnv(key); set {
+		return val, nil
+	}
+
+	if val := defaultString(key); val != "" {
+		return val, nil
+	}
+
+	return "", fmt.Errorf("%s: %w", key, ErrNotSet)
+}
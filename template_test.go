@@ -0,0 +1,92 @@
+package dotenv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGetTemplate(t *testing.T) {
+	unsetenv(t, "TEMPLATE_MISSING")
+	t.Setenv("TEMPLATE_GREETING", "Hello {{.}}")
+	t.Setenv("TEMPLATE_INVALID", "Hello {{.")
+
+	tmpl, err := GetTemplate("TEMPLATE_GREETING")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, "world"); err != nil {
+		t.Fatal(err)
+	} else if out.String() != "Hello world" {
+		t.Errorf(`expected "Hello world"; got %q`, out.String())
+	}
+
+	if again, _ := GetTemplate("TEMPLATE_GREETING"); again != tmpl {
+		t.Error("expected the compiled template to be cached")
+	}
+
+	if _, err := GetTemplate("TEMPLATE_INVALID"); err == nil || !strings.Contains(err.Error(), "TEMPLATE_INVALID") {
+		t.Errorf("expected an invalid template to be reported, naming the key; got %v", err)
+	}
+
+	if _, err := GetTemplate("TEMPLATE_MISSING"); !errors.Is(err, ErrNotSet) {
+		t.Errorf("expected a missing template to be reported as not set; got %v", err)
+	}
+}
+
+func TestGetTemplateReplacesCache(t *testing.T) {
+	t.Setenv("TEMPLATE_CHANGING", "first")
+
+	if _, err := GetTemplate("TEMPLATE_CHANGING"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("TEMPLATE_CHANGING", "second")
+
+	tmpl, err := GetTemplate("TEMPLATE_CHANGING")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, nil); err != nil {
+		t.Fatal(err)
+	} else if out.String() != "second" {
+		t.Errorf(`expected the template to follow the new value "second"; got %q`, out.String())
+	}
+
+	templateMutex.Lock()
+	defer templateMutex.Unlock()
+
+	if cached := templates["TEMPLATE_CHANGING"]; cached.src != "second" {
+		t.Errorf(`expected the cache entry to be replaced with "second"; got %q`, cached.src)
+	}
+}
+
+func TestGetHTMLTemplate(t *testing.T) {
+	unsetenv(t, "HTML_TEMPLATE_MISSING")
+	t.Setenv("HTML_TEMPLATE_GREETING", "<p>{{.}}</p>")
+	t.Setenv("HTML_TEMPLATE_INVALID", "<p>{{.</p>")
+
+	tmpl, err := GetHTMLTemplate("HTML_TEMPLATE_GREETING")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, "<b>"); err != nil {
+		t.Fatal(err)
+	} else if out.String() != "<p>&lt;b&gt;</p>" {
+		t.Errorf("expected the value to be escaped; got %q", out.String())
+	}
+
+	if _, err := GetHTMLTemplate("HTML_TEMPLATE_INVALID"); err == nil {
+		t.Error("expected an invalid template to be reported")
+	}
+
+	if _, err := GetHTMLTemplate("HTML_TEMPLATE_MISSING"); !errors.Is(err, ErrNotSet) {
+		t.Errorf("expected a missing template to be reported as not set; got %v", err)
+	}
+}
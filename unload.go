@@ -0,0 +1,97 @@
+package dotenv
+
+import (
+	"os"
+	"sync"
+)
+
+// A layer of changes made to the environment by a single load, in the order they were made.
+type layer struct {
+	changes []prior
+}
+
+// The state of an environment variable before a load changed it.
+type prior struct {
+	key    string
+	value  string
+	set    bool
+	source int
+	loaded bool
+}
+
+// Track the changes made by each load, so they may be unloaded in turn.
+var layers []*layer
+var layerMutex sync.Mutex
+
+// Unload reverses the changes made to the environment by the most recent load, restoring the
+// variables it changed to their prior values and unsetting any it created.  Each load, e.g. each
+// call to Load or LoadFiles, is a separate layer, so repeated calls to Unload peel back one load at
+// a time.  Does nothing if there's nothing left to unload.  Thread-safe.
+func Unload() error {
+	layerMutex.Lock()
+	defer layerMutex.Unlock()
+
+	if len(layers) == 0 {
+		return nil
+	}
+
+	top := layers[len(layers)-1]
+
+	for i := len(top.changes) - 1; i >= 0; i-- {
+		p := top.changes[i]
+
+		if p.set {
+			if err := os.Setenv(p.key, p.value); err != nil {
+				return err
+			}
+		} else if err := os.Unsetenv(p.key); err != nil {
+			return err
+		}
+
+		restoreSource(p)
+		top.changes = top.changes[:i]
+	}
+
+	layers = layers[:len(layers)-1]
+
+	return nil
+}
+
+// Record the current state of the variable before the loader changes it.  Only the first change to
+// each variable is recorded, as that's the value to restore.
+func (l *loader) record(key string) {
+	layerMutex.Lock()
+	defer layerMutex.Unlock()
+
+	if l.layer == nil {
+		l.layer = &layer{}
+		l.recorded = make(map[string]bool)
+		layers = append(layers, l.layer)
+	}
+
+	if l.recorded[key] {
+		return
+	}
+	l.recorded[key] = true
+
+	p := prior{key: key}
+	p.value, p.set = os.LookupEnv(key)
+
+	loadedMutex.RLock()
+	p.source, p.loaded = sources[key]
+	loadedMutex.RUnlock()
+
+	l.layer.changes = append(l.layer.changes, p)
+}
+
+// Put back where the variable came from before it was changed.
+func restoreSource(p prior) {
+	loadedMutex.Lock()
+	defer loadedMutex.Unlock()
+
+	if p.loaded {
+		sources[p.key] = p.source
+	} else {
+		delete(sources, p.key)
+	}
+}
@@ -0,0 +1,305 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types parsed specially, rather than by their kind.
+var (
+	durationType  = reflect.TypeOf(time.Duration(0))
+	timeType      = reflect.TypeOf(time.Time{})
+	urlType       = reflect.TypeOf((*url.URL)(nil))
+	ipType        = reflect.TypeOf(net.IP(nil))
+	cidrType      = reflect.TypeOf((*net.IPNet)(nil))
+	cidrSliceType = reflect.TypeOf([]*net.IPNet(nil))
+	byteSizeType  = reflect.TypeOf(ByteSize(0))
+	regexpType    = reflect.TypeOf((*regexp.Regexp)(nil))
+	mapType       = reflect.TypeOf(map[string]string(nil))
+	logLevelType  = reflect.TypeOf(slog.Level(0))
+	timeZoneType  = reflect.TypeOf((*time.Location)(nil))
+	portType      = reflect.TypeOf(Port(0))
+)
+
+// Unmarshal populates the struct pointed to by v from the environment, using the same parsing
+// rules as the getters.  Each field's `env` tag names its environment variable:
+//
+//	type Config struct {
+//		Port    int           `env:"PORT" default:"8080"`
+//		DSN     string        `env:"DATABASE_URL" required:"true"`
+//		Timeout time.Duration `env:"TIMEOUT" default:"30s"`
+//		DB      DBConfig      `prefix:"DB_"`
+//		Replica *string       `env:"REPLICA_URL"`
+//	}
+//
+// If the environment variable isn't set, the `default` tag is parsed instead, or the registered
+// default used.  A field tagged `required:"true"` with none of these is an error.  Fields holding
+// a struct are populated in turn, with the `prefix` tag, if any, added to their keys.  Pointer
+// fields are optional, and left nil if there's no value, including pointers to structs none of
+// whose settings are set.  Fields without an `env` tag are ignored.
+//
+// Supports the types the getters support, other integer and float sizes, and slices of them.
+// Every field is checked, and the returned error lists each field that's missing or invalid.
+func Unmarshal(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("unable to unmarshal into %T; must be a pointer to a struct", v)
+	}
+
+	_, errs := unmarshalStruct(rv.Elem(), "", "")
+	return errors.Join(errs...)
+}
+
+// Populate each field of the struct, adding the prefix to the keys.  The path names the struct in
+// errors, e.g. "DB.".  Returns true if any field was assigned a value.
+func unmarshalStruct(rv reflect.Value, prefix, path string) (bool, []error) {
+	var errs []error
+	var assigned bool
+
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		name := path + field.Name
+
+		key, tagged := field.Tag.Lookup("env")
+		if !tagged {
+			if nested, ok := nestedStruct(fv); ok {
+				set, nestedErrs := unmarshalStruct(nested, prefix+field.Tag.Get("prefix"), name+".")
+
+				// leave an optional section nil if none of its settings are set
+				if fv.Kind() == reflect.Pointer && fv.IsNil() {
+					if !set {
+						continue
+					}
+
+					fv.Set(nested.Addr())
+				}
+
+				assigned = assigned || set
+				errs = append(errs, nestedErrs...)
+			}
+
+			continue
+		}
+
+		key = prefix + key
+
+		raw, set := lookupEnv(key)
+		if !set {
+			raw, set = field.Tag.Lookup("default")
+		}
+
+		if !set {
+			if registeredDefault(fv, key) {
+				assigned = true
+				continue
+			}
+
+			if field.Tag.Get("required") == "true" {
+				errs = append(errs, fmt.Errorf("field %s: %s: %w", name, key, ErrNotSet))
+			}
+
+			continue
+		}
+
+		assigned = true
+
+		target := fv
+		if fv.Kind() == reflect.Pointer && !parsedSpecially(fv.Type()) {
+			target = reflect.New(fv.Type().Elem()).Elem()
+		}
+
+		if err := parseValue(target, raw); err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", name, &ParseValueError{Key: key, Raw: raw, Type: target.Type().String(), Err: err}))
+			continue
+		}
+
+		if target != fv {
+			fv.Set(target.Addr())
+		}
+	}
+
+	return assigned, errs
+}
+
+// Returns the struct held by the field.  If the field is a nil pointer to a struct, returns a new
+// struct, which is only set on the field if any of its settings are set.
+func nestedStruct(fv reflect.Value) (reflect.Value, bool) {
+	switch {
+	case fv.Kind() == reflect.Struct && fv.Type() != timeType:
+		return fv, true
+	case fv.Kind() == reflect.Pointer && fv.Type().Elem().Kind() == reflect.Struct && !parsedSpecially(fv.Type()):
+		if fv.IsNil() {
+			return reflect.New(fv.Type().Elem()).Elem(), true
+		}
+
+		return fv.Elem(), true
+	}
+
+	return reflect.Value{}, false
+}
+
+// Set the field to the registered default for the key, if there's one of the same type.
+func registeredDefault(fv reflect.Value, key string) bool {
+	descriptor, ok := Default(key)
+	if !ok {
+		return false
+	}
+
+	dv := reflect.ValueOf(descriptor.DefaultValue)
+	switch {
+	case dv.Type().AssignableTo(fv.Type()):
+		fv.Set(dv)
+	case fv.Kind() == reflect.Pointer && dv.Type().AssignableTo(fv.Type().Elem()):
+		ptr := reflect.New(fv.Type().Elem())
+		ptr.Elem().Set(dv)
+		fv.Set(ptr)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Pointer types that are values in their own right, rather than optional values.
+func parsedSpecially(t reflect.Type) bool {
+	return t == urlType || t == cidrType || t == regexpType || t == timeZoneType
+}
+
+// Parse the raw value into the field, following the rules of the matching getter.
+func parseValue(fv reflect.Value, raw string) error {
+	switch fv.Type() {
+	case durationType:
+		d, err := parseDuration(raw, currentOptions().DurationUnit)
+		fv.SetInt(int64(d))
+		return err
+	case timeType:
+		t, err := parseTime(raw)
+		fv.Set(reflect.ValueOf(t))
+		return err
+	case urlType:
+		u, err := parseURL(raw)
+		fv.Set(reflect.ValueOf(u))
+		return err
+	case ipType:
+		ip := net.ParseIP(raw)
+		if ip == nil {
+			return errors.New("invalid IP address")
+		}
+		fv.Set(reflect.ValueOf(ip))
+		return nil
+	case cidrType:
+		_, network, err := net.ParseCIDR(raw)
+		fv.Set(reflect.ValueOf(network))
+		return err
+	case cidrSliceType:
+		networks, err := parseCIDRs(raw)
+		fv.Set(reflect.ValueOf(networks))
+		return err
+	case byteSizeType:
+		size, err := parseBytes(raw)
+		fv.SetInt(size)
+		return err
+	case regexpType:
+		re, err := regexp.Compile(raw)
+		fv.Set(reflect.ValueOf(re))
+		return err
+	case mapType:
+		m, err := parseMap(raw, ";", "=")
+		fv.Set(reflect.ValueOf(m))
+		return err
+	case logLevelType:
+		level, err := parseLogLevel(raw)
+		fv.SetInt(int64(level))
+		return err
+	case timeZoneType:
+		loc, err := parseTimeZone(raw)
+		fv.Set(reflect.ValueOf(loc))
+		return err
+	case portType:
+		port, err := parsePort(raw)
+		fv.SetInt(int64(port))
+		return err
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Bool:
+		switch {
+		case strings.EqualFold(raw, "true"):
+			fv.SetBool(true)
+		case strings.EqualFold(raw, "false"):
+			fv.SetBool(false)
+		default:
+			return errors.New(`must be "true" or "false"`)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 10, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		return parseSlice(fv, raw)
+	default:
+		return fmt.Errorf("unsupported type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// Parse the comma-separated list into the slice.  Strings follow the rules of GetStringSlice, and
+// the other types those of parseList, skipping empty durations.
+func parseSlice(fv reflect.Value, raw string) error {
+	elemType := fv.Type().Elem()
+
+	var elems []string
+	if elemType.Kind() == reflect.String {
+		elems = splitList(raw, ",")
+	} else {
+		for _, elem := range strings.Split(raw, ",") {
+			elem = strings.TrimSpace(elem)
+			if elem == "" && elemType == durationType {
+				continue
+			}
+
+			elems = append(elems, elem)
+		}
+	}
+
+	sliced := reflect.MakeSlice(fv.Type(), len(elems), len(elems))
+	for i, elem := range elems {
+		if err := parseValue(sliced.Index(i), elem); err != nil {
+			return err
+		}
+	}
+
+	fv.Set(sliced)
+	return nil
+}
@@ -0,0 +1,129 @@
+package dotenv
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnmarshal(t *testing.T) {
+	type db struct {
+		Host string `env:"HOST" default:"localhost"`
+		Port int    `env:"PORT"`
+	}
+
+	type config struct {
+		Port    int           `env:"UNMARSHAL_PORT" default:"8080"`
+		DSN     string        `env:"UNMARSHAL_DSN" required:"true"`
+		Timeout time.Duration `env:"UNMARSHAL_TIMEOUT" default:"30s"`
+		Hosts   []string      `env:"UNMARSHAL_HOSTS"`
+		Ratio   float32       `env:"UNMARSHAL_RATIO"`
+		Replica *string       `env:"UNMARSHAL_REPLICA_URL"`
+		Debug   *bool         `env:"UNMARSHAL_DEBUG"`
+		DB      db            `prefix:"UNMARSHAL_DB_"`
+		Ignored string
+	}
+
+	unsetenv(t, "UNMARSHAL_PORT", "UNMARSHAL_TIMEOUT", "UNMARSHAL_REPLICA_URL", "UNMARSHAL_DEBUG", "UNMARSHAL_DB_HOST")
+	t.Setenv("UNMARSHAL_DSN", "postgres://localhost/app")
+	t.Setenv("UNMARSHAL_HOSTS", "a, b")
+	t.Setenv("UNMARSHAL_RATIO", "0.25")
+	t.Setenv("UNMARSHAL_DB_PORT", "5432")
+
+	c := config{Ignored: "untouched"}
+	if err := Unmarshal(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := config{
+		Port:    8080,
+		DSN:     "postgres://localhost/app",
+		Timeout: 30 * time.Second,
+		Hosts:   []string{"a", "b"},
+		Ratio:   0.25,
+		DB:      db{Host: "localhost", Port: 5432},
+		Ignored: "untouched",
+	}
+	if !reflect.DeepEqual(c, expected) {
+		t.Errorf("expected %+v; got %+v", expected, c)
+	}
+
+	t.Setenv("UNMARSHAL_DEBUG", "true")
+	if err := Unmarshal(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.Debug == nil || !*c.Debug {
+		t.Errorf("expected the optional Debug to be set; got %v", c.Debug)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	type config struct {
+		Port    int           `env:"UNMARSHAL_BAD_PORT"`
+		Timeout time.Duration `env:"UNMARSHAL_BAD_TIMEOUT"`
+		DSN     string        `env:"UNMARSHAL_MISSING_DSN" required:"true"`
+	}
+
+	unsetenv(t, "UNMARSHAL_MISSING_DSN")
+	t.Setenv("UNMARSHAL_BAD_PORT", "808O")
+	t.Setenv("UNMARSHAL_BAD_TIMEOUT", "soon")
+
+	var c config
+	err := Unmarshal(&c)
+	if err == nil {
+		t.Fatal("expected the invalid and missing fields to be reported")
+	}
+
+	for _, expected := range []string{"field Port: UNMARSHAL_BAD_PORT", "field Timeout: UNMARSHAL_BAD_TIMEOUT", "field DSN: UNMARSHAL_MISSING_DSN"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected the error to include %q; got %q", expected, err)
+		}
+	}
+
+	if !errors.Is(err, ErrNotSet) {
+		t.Error("expected the missing field to be reported as ErrNotSet")
+	}
+
+	var perr *ParseValueError
+	if !errors.As(err, &perr) || perr.Key != "UNMARSHAL_BAD_PORT" {
+		t.Errorf("expected a *ParseValueError for the port; got %v", perr)
+	}
+
+	if err := Unmarshal(c); err == nil {
+		t.Error("expected a struct that isn't a pointer to be rejected")
+	}
+}
+
+func TestUnmarshalOptionalSection(t *testing.T) {
+	type replica struct {
+		URL  string `env:"URL" required:"true"`
+		Port int    `env:"PORT"`
+	}
+
+	type config struct {
+		Name    string   `env:"UNMARSHAL_NAME"`
+		Replica *replica `prefix:"UNMARSHAL_REPLICA_"`
+		Cache   *replica `prefix:"UNMARSHAL_CACHE_"`
+	}
+
+	unsetenv(t, "UNMARSHAL_NAME", "UNMARSHAL_REPLICA_URL", "UNMARSHAL_REPLICA_PORT", "UNMARSHAL_CACHE_URL", "UNMARSHAL_CACHE_PORT")
+	t.Setenv("UNMARSHAL_CACHE_PORT", "6379")
+
+	var c config
+	err := Unmarshal(&c)
+
+	if c.Replica != nil {
+		t.Errorf("expected the replica section with none of its settings set to be nil; got %+v", c.Replica)
+	}
+
+	if c.Cache == nil || c.Cache.Port != 6379 {
+		t.Errorf("expected the cache section to be set; got %+v", c.Cache)
+	}
+
+	if err == nil {
+		t.Error("expected the cache section's missing URL to be reported")
+	}
+}
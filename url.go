@@ -0,0 +1,74 @@
+package dotenv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// The largest response LoadURL accepts by default.
+const defaultURLMaxSize = 4 << 20
+
+// LoadURL fetches the environment settings in the .env file format from the url, such as a
+// bootstrap file served by a configuration service, and loads them just like LoadFiles.  The
+// request honors the cancellation and deadline of ctx.  Use the URLHeaders option to authenticate
+// the request, e.g. with a bearer token.
+//
+// The response can't include other files with "source", and $(command) is never run, even with the
+// AllowCommandSubstitution option, so a configuration service can't read local files or run
+// commands.  Returns an error mentioning the url if the response isn't a 200 OK, or is larger than
+// the URLMaxSize option, 4MB by default.
+func LoadURL(ctx context.Context, url string) error {
+	opts := currentOptions()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", url, err)
+	}
+
+	for key, values := range opts.URLHeaders {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to load %s: %s", url, resp.Status)
+	}
+
+	maxSize := opts.urlMaxSize()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", url, err)
+	}
+
+	if int64(len(data)) > maxSize {
+		return fmt.Errorf("unable to load %s: response larger than %d bytes", url, maxSize)
+	}
+
+	l := newLoader()
+
+	// the response can't run commands or include local files
+	p := newParser(nil, l.lookup, url)
+	p.opts.AllowCommandSubstitution = false
+
+	assignments, err := p.run(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("unable to load %s: %w", url, err)
+	}
+
+	if err := l.apply(assignments); err != nil {
+		return err
+	}
+
+	return l.finish()
+}
@@ -0,0 +1,72 @@
+package dotenv
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Serve the body as a .env file, returning the URL of the server.
+func serveEnv(t *testing.T, body string) string {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestLoadURL(t *testing.T) {
+	unsetenv(t, "URL_HOST", "URL_PORT")
+
+	if err := LoadURL(context.Background(), serveEnv(t, "URL_HOST=example.com\nURL_PORT=8080\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if host, port := os.Getenv("URL_HOST"), os.Getenv("URL_PORT"); host != "example.com" || port != "8080" {
+		t.Errorf("expected URL_HOST=example.com and URL_PORT=8080; got %q and %q", host, port)
+	}
+}
+
+func TestLoadURLSource(t *testing.T) {
+	unsetenv(t, "URL_SECRET_FROM_DISK")
+
+	local := writeFile(t, "local.env", "URL_SECRET_FROM_DISK=secret\n")
+
+	err := LoadURL(context.Background(), serveEnv(t, "source "+local+"\n"))
+	if err == nil || !strings.Contains(err.Error(), "only files on disk may include other files") {
+		t.Errorf("expected the remote source to be rejected; got %v", err)
+	}
+
+	if _, set := os.LookupEnv("URL_SECRET_FROM_DISK"); set {
+		t.Error("expected the local file not to be loaded")
+	}
+}
+
+func TestLoadURLCommandSubstitution(t *testing.T) {
+	setOptions(t, Options{AllowCommandSubstitution: true})
+	unsetenv(t, "URL_COMMAND")
+
+	if err := LoadURL(context.Background(), serveEnv(t, "URL_COMMAND=$(echo ran)\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if val := os.Getenv("URL_COMMAND"); val != "$(echo ran)" {
+		t.Errorf("expected the command in the response not to run; got %q", val)
+	}
+}
+
+func TestLoadURLStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	err := LoadURL(context.Background(), server.URL)
+	if err == nil || !strings.Contains(err.Error(), server.URL) {
+		t.Errorf("expected an error naming the url; got %v", err)
+	}
+}
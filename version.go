@@ -0,0 +1,143 @@
+package dotenv
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is a semantic version, such as 1.4.0-beta.1+build.5.  The pre-release and build metadata
+// are kept, but ignored when comparing versions.
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+	Pre   string
+	Build string
+}
+
+// ParseVersion parses a semantic version, with an optional leading "v", e.g. "v1.4.0".  The minor
+// and patch numbers default to 0 if missing, so "1.4" is 1.4.0.  The error explains which part of
+// the version is invalid.
+func ParseVersion(val string) (Version, error) {
+	var v Version
+
+	s := strings.TrimPrefix(strings.TrimSpace(val), "v")
+
+	if i := strings.IndexByte(s, '+'); i >= 0 {
+		s, v.Build = s[:i], s[i+1:]
+	}
+
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		s, v.Pre = s[:i], s[i+1:]
+	}
+
+	if s == "" {
+		return Version{}, errors.New("missing the major version")
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return Version{}, fmt.Errorf("too many parts in %q; expected major.minor.patch", s)
+	}
+
+	names := []string{"major", "minor", "patch"}
+	numbers := []*int{&v.Major, &v.Minor, &v.Patch}
+
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || strings.HasPrefix(part, "+") {
+			return Version{}, fmt.Errorf("%s version %q is not a number", names[i], part)
+		}
+
+		*numbers[i] = n
+	}
+
+	return v, nil
+}
+
+// String formats the version, without a leading "v", e.g. "1.4.0-beta.1".
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+
+	return s
+}
+
+// Compare returns -1 if v is earlier than other, 1 if it's later, or 0 if they have the same major,
+// minor, and patch numbers.
+func (v Version) Compare(other Version) int {
+	for _, d := range []int{v.Major - other.Major, v.Minor - other.Minor, v.Patch - other.Patch} {
+		switch {
+		case d < 0:
+			return -1
+		case d > 0:
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// AtLeast returns true if v is the same as, or later than, other.
+func (v Version) AtLeast(other Version) bool {
+	return v.Compare(other) >= 0
+}
+
+// GetSemVer returns the environment variable as a semantic version, such as
+// MIN_CLIENT_VERSION=1.4.0.  See ParseVersion.  If the environment variable doesn't exist or isn't
+// a version, returns the default value if present, which may be registered as a Version or, with
+// RegisterVersion, a string, otherwise the zero Version.
+func GetSemVer(key string) Version {
+	if v, err := GetSemVerE(key); err == nil {
+		return v
+	}
+
+	return defaultVersion(key)
+}
+
+// GetSemVerE returns the environment variable as a semantic version, like GetSemVer, but returns a
+// *ParseValueError explaining which part of the version is invalid, rather than falling back to the
+// default.
+func GetSemVerE(key string) (Version, error) {
+	if val, set := lookupEnv(key); set {
+		v, err := ParseVersion(val)
+		if err != nil {
+			return Version{}, &ParseValueError{Key: key, Raw: val, Type: typeNames[VersionType], Err: err}
+		}
+
+		return v, nil
+	}
+
+	return defaultVersion(key), nil
+}
+
+// RegisterVersion registers a default semantic version for an environment variable read by
+// GetSemVer, given as a string such as "1.4.0".  Panics if the version is invalid, so a bad default
+// is caught at startup.
+func RegisterVersion(key, defaultValue, description string) {
+	v, err := ParseVersion(defaultValue)
+	if err != nil {
+		panic(fmt.Sprintf("invalid default for %s: %s", key, err))
+	}
+
+	register(descriptor{Var: key, DefaultValue: v, Description: description})
+}
+
+// Returns the registered default version, or the zero Version.
+func defaultVersion(key string) Version {
+	if descriptor, ok := Default(key); ok {
+		if defaultValue, ok := descriptor.DefaultValue.(Version); ok {
+			return defaultValue
+		}
+	}
+
+	return Version{}
+}
@@ -0,0 +1,112 @@
+package dotenv
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		val      string
+		expected Version
+		err      string
+	}{
+		{val: "1.4.0", expected: Version{Major: 1, Minor: 4}},
+		{val: "v2.0.1", expected: Version{Major: 2, Patch: 1}},
+		{val: "1.4", expected: Version{Major: 1, Minor: 4}},
+		{val: "3", expected: Version{Major: 3}},
+		{val: "1.4.0-beta.1+build.5", expected: Version{Major: 1, Minor: 4, Pre: "beta.1", Build: "build.5"}},
+		{val: "1.0.0+exp.sha.5114f85", expected: Version{Major: 1, Build: "exp.sha.5114f85"}},
+		{val: "", err: "missing the major version"},
+		{val: "v", err: "missing the major version"},
+		{val: "1.2.3.4", err: "too many parts"},
+		{val: "1.x.0", err: `minor version "x" is not a number`},
+		{val: "1.-2.0", err: `minor version "" is not a number`},
+		{val: "one", err: `major version "one" is not a number`},
+	}
+
+	for _, test := range tests {
+		v, err := ParseVersion(test.val)
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%q: expected an error containing %q; got %v, %v", test.val, test.err, v, err)
+			}
+			continue
+		}
+
+		if err != nil || v != test.expected {
+			t.Errorf("%q: expected %+v; got %+v, %v", test.val, test.expected, v, err)
+		}
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{a: "1.4.0", b: "1.4.0", expected: 0},
+		{a: "1.4.0", b: "1.10.0", expected: -1},
+		{a: "2.0.0", b: "1.99.99", expected: 1},
+		{a: "1.4.1", b: "1.4.0", expected: 1},
+		{a: "1.4.0-beta", b: "1.4.0", expected: 0},
+	}
+
+	for _, test := range tests {
+		a, _ := ParseVersion(test.a)
+		b, _ := ParseVersion(test.b)
+
+		if c := a.Compare(b); c != test.expected {
+			t.Errorf("%s vs %s: expected %d; got %d", test.a, test.b, test.expected, c)
+		}
+
+		if a.AtLeast(b) != (test.expected >= 0) {
+			t.Errorf("%s vs %s: expected AtLeast to be %v", test.a, test.b, test.expected >= 0)
+		}
+	}
+
+	if s := (Version{Major: 1, Minor: 4, Pre: "beta.1", Build: "5"}).String(); s != "1.4.0-beta.1+5" {
+		t.Errorf("expected 1.4.0-beta.1+5; got %s", s)
+	}
+}
+
+func TestGetSemVer(t *testing.T) {
+	unsetenv(t, "SEMVER_MIN", "SEMVER_MISSING")
+	RegisterVersion("SEMVER_MIN", "1.2.0", "The minimum client version")
+
+	if v := GetSemVer("SEMVER_MIN"); v.String() != "1.2.0" {
+		t.Errorf("expected the default when unset; got %s", v)
+	}
+
+	if v, err := GetSemVerE("SEMVER_MISSING"); err != nil || v != (Version{}) {
+		t.Errorf("expected the zero Version when unset without a default; got %s, %v", v, err)
+	}
+
+	t.Setenv("SEMVER_MIN", "v1.4.0")
+	if v, err := GetSemVerE("SEMVER_MIN"); err != nil || v.String() != "1.4.0" {
+		t.Errorf("expected SEMVER_MIN; got %s, %v", v, err)
+	}
+
+	t.Setenv("SEMVER_MIN", "1.x")
+	_, err := GetSemVerE("SEMVER_MIN")
+
+	var perr *ParseValueError
+	if !errors.As(err, &perr) || perr.Key != "SEMVER_MIN" {
+		t.Errorf("expected a *ParseValueError naming the key; got %v", err)
+	}
+
+	if v := GetSemVer("SEMVER_MIN"); v.String() != "1.2.0" {
+		t.Errorf("expected the default when invalid; got %s", v)
+	}
+}
+
+func TestRegisterVersionInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected an invalid default version to panic")
+		}
+	}()
+
+	RegisterVersion("SEMVER_INVALID", "1.x", "An invalid default")
+}
@@ -0,0 +1,187 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// How often Watch checks the file for changes, unless configured by the WatchInterval option.
+const defaultWatchInterval = time.Second
+
+// Watch monitors the .env file for changes, such as edits while a local development server is
+// running.  When the file changes, re-parses it and applies any settings whose values differ from
+// the current environment, then calls onChange with the names of the variables that changed.
+// Variables removed from the file are left alone.
+//
+// The file is parsed the same way as the most recent load, if it read the file, or following the
+// NoOverride and KeyFilter options otherwise.  Variables are looked up as they were before the file
+// was loaded, so "?=" and "+=" give the same results as the first time, rather than appending
+// again on every change.
+//
+// The file is polled for changes to its modification time or size.  If the file can't be parsed
+// when it changes, the error is passed to the WatchErrorHandler option, if set, and the watcher
+// keeps going.  Call the returned stop function to stop watching the file.
+func Watch(filename string, onChange func(changed []string)) (stop func(), err error) {
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := currentOptions()
+	watched := watchLoad(filename)
+
+	interval := opts.WatchInterval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		modTime, size := info.ModTime(), info.Size()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(filename)
+			if err != nil {
+				watchError(opts, err)
+				continue
+			}
+
+			if info.ModTime().Equal(modTime) && info.Size() == size {
+				continue
+			}
+			modTime, size = info.ModTime(), info.Size()
+
+			changed, err := reload(watched)
+			if err != nil {
+				watchError(opts, err)
+				continue
+			}
+
+			if len(changed) > 0 && onChange != nil {
+				onChange(changed)
+			}
+		}
+	}()
+
+	var once sync.Once
+
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}, nil
+}
+
+// Describe how to re-parse the watched file.  If the most recent load read the file, it's parsed
+// the same way.  Otherwise it follows the current options, and variables are looked up as they
+// were before any load changed them.
+func watchLoad(filename string) *lastLoad {
+	lastMutex.Lock()
+	defer lastMutex.Unlock()
+
+	if last != nil {
+		for _, f := range last.files {
+			if f.json || f.dir || filepath.Clean(f.name) != filepath.Clean(filename) {
+				continue
+			}
+
+			before := make(map[string]prior, len(last.before))
+			for key, p := range last.before {
+				before[key] = p
+			}
+
+			return &lastLoad{
+				files:    []loadedFile{{name: filename}},
+				override: last.override,
+				existing: last.existing,
+				filter:   last.filter,
+				prefix:   last.prefix,
+				before:   before,
+			}
+		}
+	}
+
+	layerMutex.Lock()
+	before := make(map[string]prior)
+	for _, l := range layers {
+		for _, p := range l.changes {
+			if _, ok := before[p.key]; !ok {
+				before[p.key] = p
+			}
+		}
+	}
+	layerMutex.Unlock()
+
+	l := newLoader()
+	if !l.override {
+		for key, p := range before {
+			l.existing[key] = p.set
+		}
+	}
+
+	return &lastLoad{
+		files:    []loadedFile{{name: filename}},
+		override: l.override,
+		existing: l.existing,
+		filter:   l.filter,
+		before:   before,
+	}
+}
+
+// Re-parse the file and apply any settings that differ from the current environment.  Returns the
+// keys that changed.
+func reload(watched *lastLoad) ([]string, error) {
+	probe, err := watched.probe()
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []assignment
+
+	// only the last assignment to each key matters
+	for key, a := range probe.origins {
+		current, set := os.LookupEnv(key)
+		if (a.Unset && !set) || (!a.Unset && set && current == a.Value) {
+			continue
+		}
+
+		changes = append(changes, a)
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+
+	changed := make([]string, 0, len(changes))
+	for _, a := range changes {
+		changed = append(changed, a.Key)
+	}
+
+	if err := watched.apply(changes); err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+// Report an error from the watcher to the configured handler, if any.
+func watchError(opts Options, err error) {
+	if opts.WatchErrorHandler != nil {
+		opts.WatchErrorHandler(err)
+	}
+}
@@ -0,0 +1,95 @@
+package dotenv
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Watch the file, returning a channel receiving the keys changed each time it's reloaded.
+func watchFile(t *testing.T, filename string) <-chan []string {
+	t.Helper()
+
+	changes := make(chan []string, 10)
+
+	stop, err := Watch(filename, func(changed []string) { changes <- changed })
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(stop)
+
+	return changes
+}
+
+// Rewrite the file and wait for the watcher to apply the changes.
+func rewrite(t *testing.T, filename, contents string, changes <-chan []string) []string {
+	t.Helper()
+
+	if err := os.WriteFile(filename, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case changed := <-changes:
+		return changed
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the file to be reloaded")
+		return nil
+	}
+}
+
+func TestWatchAppend(t *testing.T) {
+	setOptions(t, Options{AppendSeparator: ",", WatchInterval: 10 * time.Millisecond})
+	unsetenv(t, "WATCH_LEVEL", "WATCH_PORT")
+	t.Setenv("WATCH_FEATURES", "alpha")
+
+	filename := writeFile(t, ".env", "WATCH_LEVEL ?= info\nWATCH_FEATURES += beta\n")
+	if err := LoadFiles(filename); err != nil {
+		t.Fatal(err)
+	}
+
+	changes := watchFile(t, filename)
+
+	for i, port := range []string{"80", "808", "8080"} {
+		changed := rewrite(t, filename, "WATCH_LEVEL ?= info\nWATCH_FEATURES += beta\nWATCH_PORT="+port+"\n", changes)
+
+		if len(changed) != 1 || changed[0] != "WATCH_PORT" {
+			t.Errorf("change %d: expected only WATCH_PORT to change; got %v", i, changed)
+		}
+
+		if val := os.Getenv("WATCH_FEATURES"); val != "alpha,beta" {
+			t.Errorf("change %d: expected WATCH_FEATURES to be alpha,beta; got %q", i, val)
+		}
+
+		if val := os.Getenv("WATCH_LEVEL"); val != "info" {
+			t.Errorf("change %d: expected WATCH_LEVEL to be info; got %q", i, val)
+		}
+	}
+}
+
+func TestWatchOptions(t *testing.T) {
+	setOptions(t, Options{
+		NoOverride:    true,
+		KeyFilter:     func(key string) bool { return strings.HasPrefix(key, "WATCH_") },
+		WatchInterval: 10 * time.Millisecond,
+	})
+	unsetenv(t, "WATCH_NAME", "OTHER_NAME")
+	t.Setenv("WATCH_HOST", "example.com")
+
+	filename := writeFile(t, ".env", "WATCH_NAME=a\n")
+	changes := watchFile(t, filename)
+
+	changed := rewrite(t, filename, "WATCH_NAME=bb\nWATCH_HOST=localhost\nOTHER_NAME=c\n", changes)
+	if len(changed) != 1 || changed[0] != "WATCH_NAME" {
+		t.Errorf("expected only WATCH_NAME to change; got %v", changed)
+	}
+
+	if val := os.Getenv("WATCH_HOST"); val != "example.com" {
+		t.Errorf("expected WATCH_HOST to be left alone; got %q", val)
+	}
+
+	if _, set := os.LookupEnv("OTHER_NAME"); set {
+		t.Error("expected OTHER_NAME to be filtered out")
+	}
+}